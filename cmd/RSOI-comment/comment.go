@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/andreymgn/RSOI-comment/pkg/comment"
 	"github.com/andreymgn/RSOI/pkg/tracer"
 )
 
-func runComment(port int, connString, jaegerAddr string) error {
+func runComment(conf comment.Config, jaegerAddr string, shutdownTimeout time.Duration) error {
 	tracer, closer, err := tracer.NewTracer("comment", jaegerAddr)
 	if err != nil {
 		return err
@@ -13,10 +20,52 @@ func runComment(port int, connString, jaegerAddr string) error {
 
 	defer closer.Close()
 
-	server, err := comment.NewServer(connString)
+	server, err := comment.NewServer(conf)
 	if err != nil {
 		return err
 	}
 
-	return server.Start(port, tracer)
+	errs := make(chan error, 2)
+	running := 1
+	go func() {
+		errs <- server.Start(tracer)
+	}()
+
+	if conf.Gateway.Port != 0 {
+		running++
+		go func() {
+			errs <- server.StartGateway()
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	defer signal.Stop(signals)
+
+	select {
+	case err := <-errs:
+		server.Stop(context.Background())
+		return wait(errs, running-1, err)
+	case sig := <-signals:
+		log.Printf("received %v, shutting down", sig)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Stop(ctx); err != nil {
+		return err
+	}
+
+	return wait(errs, running, nil)
+}
+
+// wait receives n results from errs and returns the first error
+func wait(errs <-chan error, n int, err error) error {
+	for ; n > 0; n-- {
+		if e := <-errs; err == nil {
+			err = e
+		}
+	}
+
+	return err
 }
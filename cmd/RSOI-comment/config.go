@@ -0,0 +1,470 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andreymgn/RSOI-comment/pkg/comment"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// config describes settings of comment service.
+// Settings are applied in order: defaults, config file, environment, command line flags.
+type config struct {
+	Port              int           `yaml:"port"`
+	MetricsPort       int           `yaml:"metrics_port"`
+	JaegerAddr        string        `yaml:"jaeger_addr"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+	ConnectionTimeout time.Duration `yaml:"connection_timeout"`
+	RequestTimeout    time.Duration `yaml:"request_timeout"`
+
+	DB struct {
+		Conn                 string        `yaml:"conn"`
+		Replicas             []string      `yaml:"replicas"`
+		MaxOpenConns         int           `yaml:"max_open_conns"`
+		MaxIdleConns         int           `yaml:"max_idle_conns"`
+		ConnMaxLifetime      time.Duration `yaml:"conn_max_lifetime"`
+		ReadYourWritesWindow time.Duration `yaml:"read_your_writes_window"`
+	} `yaml:"db"`
+
+	TLS struct {
+		Cert       string `yaml:"cert"`
+		Key        string `yaml:"key"`
+		ClientCA   string `yaml:"client_ca"`
+		MinVersion string `yaml:"min_version"`
+		Insecure   bool   `yaml:"insecure"`
+	} `yaml:"tls"`
+
+	Gateway struct {
+		Port       int    `yaml:"port"`
+		CA         string `yaml:"ca"`
+		ServerName string `yaml:"server_name"`
+		Cert       string `yaml:"cert"`
+		Key        string `yaml:"key"`
+	} `yaml:"gateway"`
+
+	Cache struct {
+		Size int           `yaml:"size"`
+		TTL  time.Duration `yaml:"ttl"`
+	} `yaml:"cache"`
+
+	Auth struct {
+		Identities map[string]string   `yaml:"identities"`
+		Policy     map[string][]string `yaml:"policy"`
+		Gateway    string              `yaml:"gateway"`
+	} `yaml:"auth"`
+
+	Tenants struct {
+		Default    string                    `yaml:"default"`
+		Identities map[string]string         `yaml:"identities"`
+		Trusted    []string                  `yaml:"trusted"`
+		Settings   map[string]tenantSettings `yaml:"settings"`
+	} `yaml:"tenants"`
+
+	Guests struct {
+		Secret       string        `yaml:"secret"`
+		Difficulty   int           `yaml:"difficulty"`
+		ChallengeTTL time.Duration `yaml:"challenge_ttl"`
+		Publish      bool          `yaml:"publish"`
+	} `yaml:"guests"`
+
+	Moderation struct {
+		Identities []string          `yaml:"identities"`
+		Reasons    map[string]string `yaml:"reasons"`
+	} `yaml:"moderation"`
+
+	Drafts struct {
+		ExpireAfterDays int `yaml:"expire_after_days"`
+	} `yaml:"drafts"`
+
+	Retention struct {
+		Period    time.Duration `yaml:"period"`
+		Interval  time.Duration `yaml:"interval"`
+		BatchSize int           `yaml:"batch_size"`
+		DryRun    bool          `yaml:"dry_run"`
+	} `yaml:"retention"`
+
+	Limits limits `yaml:"limits"`
+
+	Features struct {
+		UserDataExport     bool `yaml:"user_data_export"`
+		UserErasure        bool `yaml:"user_erasure"`
+		BulkPostOperations bool `yaml:"bulk_post_operations"`
+		GuestComments      bool `yaml:"guest_comments"`
+	} `yaml:"features"`
+}
+
+// maxDraftDays keeps draft TTL within time.Duration range
+const maxDraftDays = 36500
+
+type limits struct {
+	MaxBodyLength   int `yaml:"max_body_length"`
+	DefaultPageSize int `yaml:"default_page_size"`
+	MaxPageSize     int `yaml:"max_page_size"`
+}
+
+// tenantSettings override global settings for a tenant
+type tenantSettings struct {
+	Limits        limits `yaml:"limits"`
+	GuestComments *bool  `yaml:"guest_comments"`
+}
+
+// serverLimits converts limits to server limits
+func (l limits) serverLimits() (comment.Limits, error) {
+	if l.DefaultPageSize > 1<<31-1 || l.MaxPageSize > 1<<31-1 {
+		return comment.Limits{}, errors.New("page size is too large")
+	}
+
+	return comment.Limits{
+		MaxBodyLength:   l.MaxBodyLength,
+		DefaultPageSize: int32(l.DefaultPageSize),
+		MaxPageSize:     int32(l.MaxPageSize),
+	}, nil
+}
+
+func defaultConfig() *config {
+	conf := new(config)
+	conf.ShutdownTimeout = 30 * time.Second
+	conf.ConnectionTimeout = 120 * time.Second
+	conf.DB.MaxOpenConns = 20
+	conf.DB.MaxIdleConns = 5
+	conf.DB.ConnMaxLifetime = 30 * time.Minute
+	conf.Cache.TTL = 5 * time.Second
+	conf.TLS.Cert = "/cert.pem"
+	conf.TLS.Key = "/key.pem"
+	conf.TLS.MinVersion = "1.2"
+	conf.Tenants.Default = "default"
+	conf.Guests.Difficulty = 16
+	conf.Guests.ChallengeTTL = 10 * time.Minute
+	conf.Drafts.ExpireAfterDays = 30
+	conf.Retention.Interval = time.Hour
+	conf.Retention.BatchSize = 1000
+	conf.Limits.MaxBodyLength = 10000
+	conf.Limits.DefaultPageSize = 10
+	conf.Limits.MaxPageSize = 100
+	conf.Features.UserDataExport = true
+	conf.Features.UserErasure = true
+	conf.Features.BulkPostOperations = true
+	return conf
+}
+
+func (conf *config) flagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("RSOI-comment", flag.ContinueOnError)
+	fs.String("config", "", "path to YAML config file (env CONFIG_FILE)")
+	fs.IntVar(&conf.Port, "port", conf.Port, "gRPC port")
+	fs.IntVar(&conf.MetricsPort, "metrics-port", conf.MetricsPort, "Prometheus metrics HTTP port, 0 disables metrics endpoint")
+	fs.StringVar(&conf.JaegerAddr, "jaeger-addr", conf.JaegerAddr, "Jaeger agent address")
+	fs.DurationVar(&conf.ShutdownTimeout, "shutdown-timeout", conf.ShutdownTimeout, "time to wait for in-flight RPCs on shutdown")
+	fs.DurationVar(&conf.ConnectionTimeout, "connection-timeout", conf.ConnectionTimeout, "connection handshake timeout")
+	fs.DurationVar(&conf.RequestTimeout, "request-timeout", conf.RequestTimeout, "RPC timeout, 0 means no timeout")
+	fs.StringVar(&conf.DB.Conn, "conn", conf.DB.Conn, "Postgres connection string")
+	fs.Var(stringsValue{&conf.DB.Replicas}, "db-replicas", "comma separated Postgres connection strings of read replicas")
+	fs.DurationVar(&conf.DB.ReadYourWritesWindow, "db-read-your-writes-window", conf.DB.ReadYourWritesWindow, "how long caller presenting session token reads from primary after a write, 0 disables")
+	fs.IntVar(&conf.DB.MaxOpenConns, "db-max-open-conns", conf.DB.MaxOpenConns, "maximum number of open database connections, 0 means unlimited")
+	fs.IntVar(&conf.DB.MaxIdleConns, "db-max-idle-conns", conf.DB.MaxIdleConns, "maximum number of idle database connections")
+	fs.DurationVar(&conf.DB.ConnMaxLifetime, "db-conn-max-lifetime", conf.DB.ConnMaxLifetime, "maximum lifetime of database connection, 0 means unlimited")
+	fs.IntVar(&conf.Cache.Size, "cache-size", conf.Cache.Size, "maximum number of cached comment lists and comments, 0 disables cache")
+	fs.DurationVar(&conf.Cache.TTL, "cache-ttl", conf.Cache.TTL, "how long comments are cached")
+	fs.StringVar(&conf.TLS.Cert, "tls-cert", conf.TLS.Cert, "path to server certificate")
+	fs.StringVar(&conf.TLS.Key, "tls-key", conf.TLS.Key, "path to server key")
+	fs.StringVar(&conf.TLS.ClientCA, "tls-client-ca", conf.TLS.ClientCA, "path to CA bundle for client certificates, enables mutual TLS")
+	fs.StringVar(&conf.TLS.MinVersion, "tls-min-version", conf.TLS.MinVersion, "minimum TLS version")
+	fs.BoolVar(&conf.TLS.Insecure, "tls-insecure", conf.TLS.Insecure, "serve plaintext, for local development only")
+	fs.IntVar(&conf.Gateway.Port, "gateway-port", conf.Gateway.Port, "HTTP/JSON gateway port, 0 disables gateway")
+	fs.StringVar(&conf.Gateway.CA, "gateway-ca", conf.Gateway.CA, "path to CA bundle used by gateway to verify server certificate, system roots if empty")
+	fs.StringVar(&conf.Gateway.ServerName, "gateway-server-name", conf.Gateway.ServerName, "server name used by gateway to verify server certificate")
+	fs.StringVar(&conf.Gateway.Cert, "gateway-cert", conf.Gateway.Cert, "path to client certificate presented by gateway")
+	fs.StringVar(&conf.Gateway.Key, "gateway-key", conf.Gateway.Key, "path to client key of gateway")
+	fs.Var(identitiesValue{&conf.Auth.Identities}, "client-identities", "comma separated certificate-name=identity pairs")
+	fs.Var(policyValue{&conf.Auth.Policy}, "authz-policy", "semicolon separated method=identity,... rules")
+	fs.StringVar(&conf.Auth.Gateway, "gateway-identity", conf.Auth.Gateway, "identity of gateway client certificate, gateway calls are authorized as its HTTP clients")
+	fs.StringVar(&conf.Tenants.Default, "tenant-default", conf.Tenants.Default, "tenant of requests which name no tenant, empty rejects such requests")
+	fs.Var(identitiesValue{&conf.Tenants.Identities}, "tenant-identities", "comma separated identity=tenant pairs")
+	fs.Var(stringsValue{&conf.Tenants.Trusted}, "tenant-trusted", "comma separated identities which may choose tenant with x-comment-tenant metadata")
+	fs.StringVar(&conf.Guests.Secret, "guest-secret", conf.Guests.Secret, "secret signing guest challenges and hashing guest emails")
+	fs.IntVar(&conf.Guests.Difficulty, "guest-difficulty", conf.Guests.Difficulty, "leading zero bits of guest proof of work, 0 requires only a signed challenge")
+	fs.DurationVar(&conf.Guests.ChallengeTTL, "guest-challenge-ttl", conf.Guests.ChallengeTTL, "how long guest challenge can be used")
+	fs.BoolVar(&conf.Guests.Publish, "guest-publish", conf.Guests.Publish, "publish guest comments without moderation")
+	fs.Var(stringsValue{&conf.Moderation.Identities}, "moderation-identities", "comma separated identities acting on behalf of moderators, which may read removed comment bodies")
+	fs.IntVar(&conf.Drafts.ExpireAfterDays, "draft-expire-after-days", conf.Drafts.ExpireAfterDays, "days drafts are kept after last save, 0 keeps drafts forever")
+	fs.DurationVar(&conf.Retention.Period, "retention-period", conf.Retention.Period, "how long removed comments keep their bodies, 0 keeps them forever")
+	fs.DurationVar(&conf.Retention.Interval, "retention-interval", conf.Retention.Interval, "time between purges of removed comments and expired drafts, 0 disables purge")
+	fs.IntVar(&conf.Retention.BatchSize, "retention-batch-size", conf.Retention.BatchSize, "maximum number of rows changed by a single purge statement")
+	fs.BoolVar(&conf.Retention.DryRun, "retention-dry-run", conf.Retention.DryRun, "only log and count rows purge would change")
+	fs.IntVar(&conf.Limits.MaxBodyLength, "max-body-length", conf.Limits.MaxBodyLength, "maximum comment length in characters, 0 means unlimited")
+	fs.IntVar(&conf.Limits.DefaultPageSize, "default-page-size", conf.Limits.DefaultPageSize, "page size used when request doesn't specify one")
+	fs.IntVar(&conf.Limits.MaxPageSize, "max-page-size", conf.Limits.MaxPageSize, "maximum page size, 0 means unlimited")
+	fs.BoolVar(&conf.Features.UserDataExport, "feature-user-data-export", conf.Features.UserDataExport, "enable ExportUserData")
+	fs.BoolVar(&conf.Features.UserErasure, "feature-user-erasure", conf.Features.UserErasure, "enable EraseUser")
+	fs.BoolVar(&conf.Features.BulkPostOperations, "feature-bulk-post-operations", conf.Features.BulkPostOperations, "enable DeleteCommentsForPost and RemoveContentForPost")
+	fs.BoolVar(&conf.Features.GuestComments, "feature-guest-comments", conf.Features.GuestComments, "allow comments without an account, tenants may override it")
+	return fs
+}
+
+// envName returns environment variable name for flag, e.g. db-max-open-conns -> DB_MAX_OPEN_CONNS
+func envName(flagName string) string {
+	return strings.ToUpper(strings.Replace(flagName, "-", "_", -1))
+}
+
+// loadConfig builds and validates config from defaults, config file, environment and args
+func loadConfig(args []string) (*config, comment.Config, error) {
+	conf := defaultConfig()
+	fs := conf.flagSet()
+	if err := fs.Parse(args); err != nil {
+		return nil, comment.Config{}, err
+	}
+
+	path := fs.Lookup("config").Value.String()
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, comment.Config{}, err
+		}
+
+		if err := yaml.UnmarshalStrict(data, conf); err != nil {
+			return nil, comment.Config{}, fmt.Errorf("parsing %s: %v", path, err)
+		}
+	}
+
+	// JAEGER-ADDR is the old name, kept for compatibility
+	if _, ok := os.LookupEnv("JAEGER_ADDR"); !ok {
+		if addr, ok := os.LookupEnv("JAEGER-ADDR"); ok {
+			conf.JaegerAddr = addr
+		}
+	}
+
+	var envErr error
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name == "config" || envErr != nil {
+			return
+		}
+
+		if value, ok := os.LookupEnv(envName(f.Name)); ok {
+			if err := f.Value.Set(value); err != nil {
+				envErr = fmt.Errorf("invalid value %q for %s: %v", value, envName(f.Name), err)
+			}
+		}
+	})
+
+	if envErr != nil {
+		return nil, comment.Config{}, envErr
+	}
+
+	// flags have the highest priority, so apply them once again
+	if err := fs.Parse(args); err != nil {
+		return nil, comment.Config{}, err
+	}
+
+	serverConf, err := conf.validate()
+	if err != nil {
+		return nil, comment.Config{}, err
+	}
+
+	return conf, serverConf, nil
+}
+
+// validate checks config and converts it to server config
+func (conf *config) validate() (comment.Config, error) {
+	var serverConf comment.Config
+	if conf.MetricsPort < 0 || conf.MetricsPort > 65535 {
+		return serverConf, fmt.Errorf("invalid metrics port %d", conf.MetricsPort)
+	}
+
+	if conf.MetricsPort != 0 && conf.MetricsPort == conf.Port {
+		return serverConf, errors.New("metrics port must differ from gRPC port")
+	}
+
+	if conf.MetricsPort != 0 && conf.MetricsPort == conf.Gateway.Port {
+		return serverConf, errors.New("metrics port must differ from gateway port")
+	}
+
+	if conf.ShutdownTimeout <= 0 {
+		return serverConf, errors.New("shutdown timeout must be positive")
+	}
+
+	if conf.Drafts.ExpireAfterDays < 0 || conf.Drafts.ExpireAfterDays > maxDraftDays {
+		return serverConf, fmt.Errorf("draft expiration must be between 0 and %d days", maxDraftDays)
+	}
+
+	serverLimits, err := conf.Limits.serverLimits()
+	if err != nil {
+		return serverConf, err
+	}
+
+	tenantSettings := make(map[string]comment.TenantSettings)
+	for tenant, settings := range conf.Tenants.Settings {
+		tenantLimits, err := settings.Limits.serverLimits()
+		if err != nil {
+			return serverConf, fmt.Errorf("tenant %s: %v", tenant, err)
+		}
+
+		tenantSettings[tenant] = comment.TenantSettings{Limits: tenantLimits, GuestComments: settings.GuestComments}
+	}
+
+	minVersion, err := comment.ParseTLSVersion(conf.TLS.MinVersion)
+	if err != nil {
+		return serverConf, err
+	}
+
+	serverConf = comment.Config{
+		Port: conf.Port,
+		DB: comment.DBConfig{
+			ConnString:           conf.DB.Conn,
+			ReplicaConnStrings:   conf.DB.Replicas,
+			MaxOpenConns:         conf.DB.MaxOpenConns,
+			MaxIdleConns:         conf.DB.MaxIdleConns,
+			ConnMaxLifetime:      conf.DB.ConnMaxLifetime,
+			ReadYourWritesWindow: conf.DB.ReadYourWritesWindow,
+		},
+		TLS: comment.TLSConfig{
+			CertFile:     conf.TLS.Cert,
+			KeyFile:      conf.TLS.Key,
+			ClientCAFile: conf.TLS.ClientCA,
+			MinVersion:   minVersion,
+			Insecure:     conf.TLS.Insecure,
+		},
+		Gateway: comment.GatewayConfig{
+			Port:       conf.Gateway.Port,
+			CAFile:     conf.Gateway.CA,
+			ServerName: conf.Gateway.ServerName,
+			CertFile:   conf.Gateway.Cert,
+			KeyFile:    conf.Gateway.Key,
+		},
+		Cache: comment.CacheConfig{
+			Size: conf.Cache.Size,
+			TTL:  conf.Cache.TTL,
+		},
+		Retention: comment.RetentionConfig{
+			Period:    conf.Retention.Period,
+			Interval:  conf.Retention.Interval,
+			BatchSize: conf.Retention.BatchSize,
+			DryRun:    conf.Retention.DryRun,
+		},
+		Auth: comment.AuthConfig{
+			Identities: conf.Auth.Identities,
+			Policy:     conf.Auth.Policy,
+			Gateway:    conf.Auth.Gateway,
+		},
+		Tenants: comment.TenantConfig{
+			Default:    conf.Tenants.Default,
+			Identities: conf.Tenants.Identities,
+			Trusted:    conf.Tenants.Trusted,
+			Settings:   tenantSettings,
+		},
+		Guests: comment.GuestConfig{
+			Secret:       conf.Guests.Secret,
+			Difficulty:   conf.Guests.Difficulty,
+			ChallengeTTL: conf.Guests.ChallengeTTL,
+			Publish:      conf.Guests.Publish,
+		},
+		Moderation: comment.ModerationConfig{
+			Identities: conf.Moderation.Identities,
+			Reasons:    conf.Moderation.Reasons,
+		},
+		Limits: serverLimits,
+		Features: comment.Features{
+			UserDataExport:     conf.Features.UserDataExport,
+			UserErasure:        conf.Features.UserErasure,
+			BulkPostOperations: conf.Features.BulkPostOperations,
+			GuestComments:      conf.Features.GuestComments,
+		},
+		ConnectionTimeout: conf.ConnectionTimeout,
+		RequestTimeout:    conf.RequestTimeout,
+		DraftTTL:          time.Duration(conf.Drafts.ExpireAfterDays) * 24 * time.Hour,
+	}
+
+	return serverConf, serverConf.Validate()
+}
+
+// stringsValue is a flag.Value for comma separated list
+type stringsValue struct {
+	s *[]string
+}
+
+func (v stringsValue) String() string {
+	if v.s == nil {
+		return ""
+	}
+
+	return strings.Join(*v.s, ",")
+}
+
+func (v stringsValue) Set(s string) error {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	*v.s = values
+	return nil
+}
+
+// identitiesValue is a flag.Value for certificate name to identity mapping
+type identitiesValue struct {
+	m *map[string]string
+}
+
+func (v identitiesValue) String() string {
+	if v.m == nil {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(*v.m))
+	for name, identity := range *v.m {
+		pairs = append(pairs, name+"="+identity)
+	}
+
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (v identitiesValue) Set(s string) error {
+	identities, err := comment.ParseIdentities(s)
+	if err != nil {
+		return err
+	}
+
+	*v.m = identities
+	return nil
+}
+
+// policyValue is a flag.Value for authorization policy
+type policyValue struct {
+	m *map[string][]string
+}
+
+func (v policyValue) String() string {
+	if v.m == nil {
+		return ""
+	}
+
+	rules := make([]string, 0, len(*v.m))
+	for method, identities := range *v.m {
+		rules = append(rules, method+"="+strings.Join(identities, ","))
+	}
+
+	sort.Strings(rules)
+	return strings.Join(rules, ";")
+}
+
+func (v policyValue) Set(s string) error {
+	policy, err := comment.ParsePolicy(s)
+	if err != nil {
+		return err
+	}
+
+	*v.m = policy
+	return nil
+}
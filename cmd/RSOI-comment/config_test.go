@@ -0,0 +1,107 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "comment-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+
+	return f.Name()
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	path := writeConfigFile(t, `
+port: 8080
+request_timeout: 5s
+db:
+  conn: postgres://file
+  max_open_conns: 7
+tls:
+  insecure: true
+`)
+	defer os.Remove(path)
+
+	os.Setenv("DB_MAX_OPEN_CONNS", "9")
+	defer os.Unsetenv("DB_MAX_OPEN_CONNS")
+
+	conf, serverConf, err := loadConfig([]string{"-config", path, "-port", "8081"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if conf.Port != 8081 {
+		t.Errorf("flag not applied: got %v want %v", conf.Port, 8081)
+	}
+
+	if serverConf.DB.MaxOpenConns != 9 {
+		t.Errorf("environment not applied: got %v want %v", serverConf.DB.MaxOpenConns, 9)
+	}
+
+	if serverConf.DB.ConnString != "postgres://file" || serverConf.RequestTimeout != 5*time.Second {
+		t.Errorf("config file not applied: %+v", serverConf)
+	}
+
+	if serverConf.Limits.MaxPageSize != 100 {
+		t.Errorf("default not applied: got %v want %v", serverConf.Limits.MaxPageSize, 100)
+	}
+
+	if serverConf.DraftTTL != 30*24*time.Hour {
+		t.Errorf("draft expiration not converted: got %v want %v", serverConf.DraftTTL, 30*24*time.Hour)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	cases := [][]string{
+		{"-conn", "postgres://", "-tls-insecure"},
+		{"-port", "8080", "-tls-insecure"},
+		{"-port", "8080", "-conn", "postgres://", "-tls-insecure", "-tls-min-version", "0.9"},
+		{"-port", "8080", "-conn", "postgres://", "-tls-insecure", "-authz-policy", "*=gateway"},
+		{"-port", "8080", "-conn", "postgres://", "-tls-insecure", "-metrics-port", "8080"},
+		{"-port", "8080", "-conn", "postgres://", "-tls-insecure", "-gateway-port", "8080"},
+		{"-port", "8080", "-conn", "postgres://", "-tls-insecure", "-gateway-port", "9090", "-metrics-port", "9090"},
+		{"-port", "8080", "-conn", "postgres://", "-tls-insecure", "-db-max-open-conns", "lots"},
+		{"-port", "8080", "-conn", "postgres://", "-tls-insecure", "-cache-size", "100", "-cache-ttl", "0"},
+	}
+
+	for _, args := range cases {
+		if _, _, err := loadConfig(args); err == nil {
+			t.Errorf("expected error for %v, got nothing", args)
+		}
+	}
+}
+
+func TestLoadConfigUnknownField(t *testing.T) {
+	path := writeConfigFile(t, "prot: 8080\n")
+	defer os.Remove(path)
+
+	if _, _, err := loadConfig([]string{"-config", path}); err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}
+
+func TestLoadConfigReplicas(t *testing.T) {
+	os.Setenv("DB_REPLICAS", "postgres://replica1, postgres://replica2")
+	defer os.Unsetenv("DB_REPLICAS")
+
+	_, serverConf, err := loadConfig([]string{"-port", "8080", "-conn", "postgres://", "-tls-insecure"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	replicas := serverConf.DB.ReplicaConnStrings
+	if len(replicas) != 2 || replicas[1] != "postgres://replica2" {
+		t.Errorf("unexpected replicas %v", replicas)
+	}
+}
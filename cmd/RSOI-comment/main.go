@@ -1,25 +1,44 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"os"
-	"strconv"
 )
 
 func main() {
-	conn := os.Getenv("CONN")
-	port, err := strconv.Atoi(os.Getenv("PORT"))
-	if err != nil {
-		log.Println("PORT parse error")
+	conf, serverConf, err := loadConfig(os.Args[1:])
+	if err == flag.ErrHelp {
 		return
 	}
 
-	jaegerAddr := os.Getenv("JAEGER-ADDR")
+	if err != nil {
+		log.Printf("invalid configuration: %v", err)
+		os.Exit(2)
+	}
+
+	if conf.TLS.Insecure {
+		log.Println("TLS is disabled, do not use this mode in production")
+	}
+
+	if conf.MetricsPort != 0 {
+		log.Printf("serving metrics on port %d\n", conf.MetricsPort)
+		go func() {
+			if err := runMetrics(conf.MetricsPort); err != nil {
+				log.Printf("metrics server finished with error %v", err)
+			}
+		}()
+	}
+
+	if conf.Gateway.Port != 0 {
+		log.Printf("serving HTTP/JSON gateway on port %d\n", conf.Gateway.Port)
+	}
 
-	log.Printf("running comment service on port %d\n", port)
-	err = runComment(port, conn, jaegerAddr)
+	log.Printf("running comment service on port %d\n", conf.Port)
+	err = runComment(serverConf, conf.JaegerAddr, conf.ShutdownTimeout)
 
 	if err != nil {
 		log.Printf("finished with error %v", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// dialOptions describes connection to comment service
+type dialOptions struct {
+	addr                      string
+	caFile, certFile, keyFile string
+	serverName                string
+	insecure                  bool
+}
+
+func (opts dialOptions) dial() (*grpc.ClientConn, error) {
+	if opts.insecure {
+		return grpc.Dial(opts.addr, grpc.WithInsecure())
+	}
+
+	config := &tls.Config{ServerName: opts.serverName, MinVersion: tls.VersionTLS12}
+	if opts.caFile != "" {
+		pem, err := ioutil.ReadFile(opts.caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", opts.caFile)
+		}
+	}
+
+	if opts.certFile != "" || opts.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.certFile, opts.keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return grpc.Dial(opts.addr, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+}
@@ -0,0 +1,447 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+// treePageSize is requested when whole tree of comments is fetched, server may return less
+const treePageSize = 100
+
+var errUsage = errors.New("invalid usage")
+
+// resource is a commented resource
+type resource struct {
+	typ, id string
+}
+
+// resourceFlags select commented resource with -post UID or -resource TYPE/ID
+type resourceFlags struct {
+	post, resource *string
+}
+
+func newResourceFlags(fs *flag.FlagSet) resourceFlags {
+	return resourceFlags{fs.String("post", "", ""), fs.String("resource", "", "")}
+}
+
+func (f resourceFlags) empty() bool {
+	return *f.post == "" && *f.resource == ""
+}
+
+// get returns selected resource, exactly one of the flags must be set
+func (f resourceFlags) get() (resource, bool) {
+	switch {
+	case *f.post != "" && *f.resource == "":
+		return resource{"post", *f.post}, true
+	case *f.post == "" && *f.resource != "":
+		i := strings.Index(*f.resource, "/")
+		if i <= 0 || i == len(*f.resource)-1 {
+			return resource{}, false
+		}
+
+		return resource{(*f.resource)[:i], (*f.resource)[i+1:]}, true
+	default:
+		return resource{}, false
+	}
+}
+
+var deleteModes = map[string]pb.DeleteMode{
+	"reject":    pb.DeleteMode_REJECT_IF_HAS_REPLIES,
+	"cascade":   pb.DeleteMode_CASCADE,
+	"tombstone": pb.DeleteMode_TOMBSTONE,
+}
+
+// removers maps -by values to removers, empty value leaves remover unspecified
+var removers = map[string]pb.Remover{
+	"":          pb.Remover_REMOVER_UNSPECIFIED,
+	"author":    pb.Remover_AUTHOR,
+	"moderator": pb.Remover_MODERATOR,
+	"system":    pb.Remover_SYSTEM,
+}
+
+// node is a comment with its replies
+type node struct {
+	comment *pb.SingleComment
+	replies []*node
+}
+
+// parseArgs parses subcommand flags and checks number of positional arguments
+func parseArgs(fs *flag.FlagSet, args []string, nArgs int) error {
+	fs.SetOutput(ioutil.Discard)
+	if err := fs.Parse(args); err != nil || fs.NArg() != nArgs {
+		return errUsage
+	}
+
+	return nil
+}
+
+// readBody returns body, reading it from stdin if body is "-"
+func readBody(body string) (string, error) {
+	if body != "-" {
+		return body, nil
+	}
+
+	data, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+func runGet(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	removedBody := fs.Bool("removed-body", false, "")
+	if err := parseArgs(fs, args, 1); err != nil {
+		return err
+	}
+
+	comment, err := client.GetComment(ctx, &pb.GetCommentRequest{Uid: fs.Arg(0), IncludeRemovedBody: *removedBody})
+	if err != nil {
+		return err
+	}
+
+	return out.comments([]*pb.SingleComment{comment})
+}
+
+func runList(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	target := newResourceFlags(fs)
+	parent := fs.String("parent", "", "")
+	user := fs.String("user", "", "")
+	page := fs.Int("page", 0, "")
+	pageSize := fs.Int("page-size", 0, "")
+	removedBody := fs.Bool("removed-body", false, "")
+	if err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	if *user != "" {
+		if !target.empty() || *parent != "" {
+			return errUsage
+		}
+
+		records, err := exportUserData(ctx, client, *user)
+		if err != nil {
+			return err
+		}
+
+		comments := make([]*pb.SingleComment, 0, len(records))
+		for _, record := range records {
+			comments = append(comments, record.Comment)
+		}
+
+		return out.comments(comments)
+	}
+
+	res, ok := target.get()
+	if !ok {
+		return errUsage
+	}
+
+	req := &pb.ListCommentsRequest{ResourceType: res.typ, ResourceId: res.id, CommentUid: *parent, PageNumber: int32(*page), PageSize: int32(*pageSize), IncludeRemovedBody: *removedBody}
+	list, err := client.ListComments(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return out.comments(list.Comments)
+}
+
+func runTree(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("tree", flag.ContinueOnError)
+	target := newResourceFlags(fs)
+	parent := fs.String("parent", "", "")
+	if err := parseArgs(fs, args, 0); err != nil {
+		return errUsage
+	}
+
+	res, ok := target.get()
+	if !ok {
+		return errUsage
+	}
+
+	nodes, err := fetchTree(ctx, client, res, *parent)
+	if err != nil {
+		return err
+	}
+
+	return out.tree(nodes)
+}
+
+// fetchTree returns all replies to parent with their replies, top level comments if parent is empty
+func fetchTree(ctx context.Context, client pb.CommentClient, target resource, parent string) ([]*node, error) {
+	var nodes []*node
+	for page := int32(0); ; page++ {
+		req := &pb.ListCommentsRequest{ResourceType: target.typ, ResourceId: target.id, CommentUid: parent, PageNumber: page, PageSize: treePageSize}
+		res, err := client.ListComments(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, comment := range res.Comments {
+			replies, err := fetchTree(ctx, client, target, comment.Uid)
+			if err != nil {
+				return nil, err
+			}
+
+			nodes = append(nodes, &node{comment, replies})
+		}
+
+		if res.PageSize <= 0 || int32(len(res.Comments)) < res.PageSize {
+			return nodes, nil
+		}
+	}
+}
+
+func runCreate(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	target := newResourceFlags(fs)
+	user := fs.String("user", "", "")
+	parent := fs.String("parent", "", "")
+	body := fs.String("body", "", "")
+	if err := parseArgs(fs, args, 0); err != nil || *user == "" || *body == "" {
+		return errUsage
+	}
+
+	res, ok := target.get()
+	if !ok {
+		return errUsage
+	}
+
+	if *parent == "" {
+		*parent = uuid.Nil.String()
+	}
+
+	text, err := readBody(*body)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.CreateCommentRequest{ResourceType: res.typ, ResourceId: res.id, UserUid: *user, ParentUid: *parent, Body: text}
+	comment, err := client.CreateComment(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return out.comments([]*pb.SingleComment{comment})
+}
+
+func runEdit(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("edit", flag.ContinueOnError)
+	body := fs.String("body", "", "")
+	if err := parseArgs(fs, args, 1); err != nil || *body == "" {
+		return errUsage
+	}
+
+	text, err := readBody(*body)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.UpdateCommentRequest{Uid: fs.Arg(0), Body: text, UpdateMask: &field_mask.FieldMask{Paths: []string{"body"}}}
+	comment, err := client.UpdateComment(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return out.comments([]*pb.SingleComment{comment})
+}
+
+func runRemove(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("remove", flag.ContinueOnError)
+	by := fs.String("by", "", "")
+	reason := fs.String("reason", "", "")
+	notes := fs.String("notes", "", "")
+	if err := parseArgs(fs, args, 1); err != nil {
+		return err
+	}
+
+	remover, ok := removers[*by]
+	if !ok {
+		return fmt.Errorf("unknown remover %q", *by)
+	}
+
+	if _, err := client.RemoveContent(ctx, &pb.RemoveContentRequest{Uid: fs.Arg(0), RemovedBy: remover, Reason: *reason, Notes: *notes}); err != nil {
+		return err
+	}
+
+	return showComment(ctx, client, fs.Arg(0), out)
+}
+
+func runRestore(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	if err := parseArgs(fs, args, 1); err != nil {
+		return err
+	}
+
+	if _, err := client.RestoreContent(ctx, &pb.RestoreContentRequest{Uid: fs.Arg(0)}); err != nil {
+		return err
+	}
+
+	return showComment(ctx, client, fs.Arg(0), out)
+}
+
+func runDelete(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("delete", flag.ContinueOnError)
+	modeName := fs.String("mode", "reject", "")
+	by := fs.String("by", "", "")
+	reason := fs.String("reason", "", "")
+	notes := fs.String("notes", "", "")
+	if err := parseArgs(fs, args, 1); err != nil {
+		return err
+	}
+
+	mode, ok := deleteModes[*modeName]
+	if !ok {
+		return fmt.Errorf("unknown delete mode %q", *modeName)
+	}
+
+	remover, ok := removers[*by]
+	if !ok {
+		return fmt.Errorf("unknown remover %q", *by)
+	}
+
+	req := &pb.DeleteCommentRequest{Uid: fs.Arg(0), Mode: mode, RemovedBy: remover, Reason: *reason, Notes: *notes}
+	res, err := client.DeleteComment(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return out.message(res)
+}
+
+func runPending(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("pending", flag.ContinueOnError)
+	page := fs.Int("page", 0, "")
+	pageSize := fs.Int("page-size", 0, "")
+	removedBody := fs.Bool("removed-body", false, "")
+	if err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	req := &pb.ListPendingCommentsRequest{PageNumber: int32(*page), PageSize: int32(*pageSize), IncludeRemovedBody: *removedBody}
+	list, err := client.ListPendingComments(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return out.comments(list.Comments)
+}
+
+func runRemoved(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("removed", flag.ContinueOnError)
+	reason := fs.String("reason", "", "")
+	page := fs.Int("page", 0, "")
+	pageSize := fs.Int("page-size", 0, "")
+	removedBody := fs.Bool("removed-body", false, "")
+	if err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	req := &pb.ListRemovedCommentsRequest{Reason: *reason, PageNumber: int32(*page), PageSize: int32(*pageSize), IncludeRemovedBody: *removedBody}
+	list, err := client.ListRemovedComments(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return out.comments(list.Comments)
+}
+
+func runReasons(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("reasons", flag.ContinueOnError)
+	if err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	res, err := client.ListRemovalReasons(ctx, &pb.ListRemovalReasonsRequest{})
+	if err != nil {
+		return err
+	}
+
+	return out.reasons(res.Reasons)
+}
+
+func runApprove(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("approve", flag.ContinueOnError)
+	if err := parseArgs(fs, args, 1); err != nil {
+		return err
+	}
+
+	comment, err := client.ApproveComment(ctx, &pb.ApproveCommentRequest{Uid: fs.Arg(0)})
+	if err != nil {
+		return err
+	}
+
+	return out.comments([]*pb.SingleComment{comment})
+}
+
+func runClaim(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("claim", flag.ContinueOnError)
+	email := fs.String("email", "", "")
+	if err := parseArgs(fs, args, 1); err != nil || *email == "" {
+		return errUsage
+	}
+
+	res, err := client.ClaimGuestComments(ctx, &pb.ClaimGuestCommentsRequest{UserUid: fs.Arg(0), Email: *email})
+	if err != nil {
+		return err
+	}
+
+	return out.message(res)
+}
+
+func runExport(ctx context.Context, client pb.CommentClient, args []string, out *printer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	if err := parseArgs(fs, args, 1); err != nil {
+		return err
+	}
+
+	records, err := exportUserData(ctx, client, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	return out.records(records)
+}
+
+// showComment prints current state of comment after it was changed
+func showComment(ctx context.Context, client pb.CommentClient, uid string, out *printer) error {
+	comment, err := client.GetComment(ctx, &pb.GetCommentRequest{Uid: uid})
+	if err != nil {
+		return err
+	}
+
+	return out.comments([]*pb.SingleComment{comment})
+}
+
+func exportUserData(ctx context.Context, client pb.CommentClient, user string) ([]*pb.UserDataRecord, error) {
+	stream, err := client.ExportUserData(ctx, &pb.ExportUserDataRequest{UserUid: user})
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*pb.UserDataRecord
+	for {
+		record, err := stream.Recv()
+		if err == io.EOF {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"testing"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// fakeClient serves ListComments from replies map keyed by parent UID
+type fakeClient struct {
+	pb.CommentClient
+	replies  map[string][]*pb.SingleComment
+	pageSize int
+}
+
+func (c *fakeClient) ListComments(ctx context.Context, req *pb.ListCommentsRequest, opts ...grpc.CallOption) (*pb.ListCommentsResponse, error) {
+	replies := c.replies[req.CommentUid]
+	start := int(req.PageNumber) * c.pageSize
+	if start > len(replies) {
+		start = len(replies)
+	}
+
+	end := start + c.pageSize
+	if end > len(replies) {
+		end = len(replies)
+	}
+
+	return &pb.ListCommentsResponse{Comments: replies[start:end], PageSize: int32(c.pageSize), PageNumber: req.PageNumber}, nil
+}
+
+func TestFetchTree(t *testing.T) {
+	first, second, third := testComment("first", false), testComment("second", false), testComment("third", false)
+	reply := testComment("reply", false)
+	client := &fakeClient{
+		replies: map[string][]*pb.SingleComment{
+			"":         {first, second, third},
+			second.Uid: {reply},
+		},
+		pageSize: 2,
+	}
+
+	nodes, err := fetchTree(context.Background(), client, resource{"post", "post"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(nodes) != 3 {
+		t.Fatalf("unexpected number of comments: got %v want %v", len(nodes), 3)
+	}
+
+	if len(nodes[1].replies) != 1 || nodes[1].replies[0].comment != reply || len(nodes[0].replies) != 0 {
+		t.Errorf("unexpected replies")
+	}
+}
+
+func TestResourceFlags(t *testing.T) {
+	cases := []struct {
+		args []string
+		want resource
+		ok   bool
+	}{
+		{[]string{"-post", "uid"}, resource{"post", "uid"}, true},
+		{[]string{"-resource", "photo/42"}, resource{"photo", "42"}, true},
+		{[]string{"-resource", "photo/a/b"}, resource{"photo", "a/b"}, true},
+		{[]string{"-resource", "photo"}, resource{}, false},
+		{[]string{"-resource", "photo/"}, resource{}, false},
+		{[]string{"-post", "uid", "-resource", "photo/42"}, resource{}, false},
+		{[]string{}, resource{}, false},
+	}
+
+	for _, c := range cases {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		f := newResourceFlags(fs)
+		if err := fs.Parse(c.args); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if got, ok := f.get(); got != c.want || ok != c.ok {
+			t.Errorf("%v: got %v, %v want %v, %v", c.args, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	cases := []struct {
+		args  []string
+		nArgs int
+		ok    bool
+	}{
+		{[]string{"uid"}, 1, true},
+		{[]string{}, 1, false},
+		{[]string{"-unknown", "uid"}, 1, false},
+		{[]string{"uid", "extra"}, 1, false},
+	}
+
+	for _, c := range cases {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		if err := parseArgs(fs, c.args, c.nArgs); (err == nil) != c.ok {
+			t.Errorf("parseArgs(%v): unexpected error %v", c.args, err)
+		}
+	}
+}
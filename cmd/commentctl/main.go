@@ -0,0 +1,117 @@
+// commentctl is an administrative client of comment service
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"google.golang.org/grpc/metadata"
+)
+
+// command is a commentctl subcommand
+type command struct {
+	usage       string
+	description string
+	run         func(ctx context.Context, client pb.CommentClient, args []string, out *printer) error
+}
+
+var commands = map[string]command{
+	"get":     {"get [-removed-body] UID", "show comment, -removed-body shows original body of removed comment to moderators", runGet},
+	"list":    {"list -post UID|-resource TYPE/ID [-parent UID] [-page N] [-page-size N] [-removed-body] | list -user UID", "list comments of resource, replies to comment or comments of user", runList},
+	"tree":    {"tree -post UID|-resource TYPE/ID [-parent UID]", "show comments of resource as a tree of replies", runTree},
+	"create":  {"create -post UID|-resource TYPE/ID -user UID [-parent UID] -body TEXT", "create comment, -body - reads body from stdin", runCreate},
+	"edit":    {"edit -body TEXT UID", "replace comment body, -body - reads body from stdin", runEdit},
+	"remove":  {"remove [-by author|moderator|system] [-reason CODE] [-notes TEXT] UID", "hide comment content, reason is required unless removed by author", runRemove},
+	"restore": {"restore UID", "show removed comment content again", runRestore},
+	"delete":  {"delete [-mode reject|cascade|tombstone] [-by author|moderator|system] [-reason CODE] [-notes TEXT] UID", "delete comment, removal is recorded if it is tombstoned", runDelete},
+	"export":  {"export UID", "export comments of user with their revisions", runExport},
+	"pending": {"pending [-page N] [-page-size N] [-removed-body]", "list comments waiting for approval", runPending},
+	"removed": {"removed [-reason CODE] [-page N] [-page-size N] [-removed-body]", "list removed comments, most recently removed first", runRemoved},
+	"reasons": {"reasons", "list reasons comments may be removed for", runReasons},
+	"approve": {"approve UID", "publish pending comment", runApprove},
+	"claim":   {"claim -email EMAIL USER_UID", "make user author of guest comments left with email", runClaim},
+}
+
+func usage(fs *flag.FlagSet) func() {
+	return func() {
+		fmt.Fprintf(fs.Output(), "Usage: commentctl [options] command [arguments]\n\nCommands:\n")
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(fs.Output(), "  %-8s %s\n", name, commands[name].description)
+		}
+
+		fmt.Fprintf(fs.Output(), "\nOptions:\n")
+		fs.PrintDefaults()
+	}
+}
+
+func main() {
+	var opts dialOptions
+	fs := flag.NewFlagSet("commentctl", flag.ExitOnError)
+	fs.StringVar(&opts.addr, "addr", "localhost:8080", "comment service address")
+	fs.StringVar(&opts.caFile, "tls-ca", "", "path to CA bundle used to verify server certificate, system roots if empty")
+	fs.StringVar(&opts.certFile, "tls-cert", "", "path to client certificate")
+	fs.StringVar(&opts.keyFile, "tls-key", "", "path to client key")
+	fs.StringVar(&opts.serverName, "tls-server-name", "", "server name used to verify server certificate")
+	fs.BoolVar(&opts.insecure, "tls-insecure", false, "connect without TLS, for local development only")
+	timeout := fs.Duration("timeout", 30*time.Second, "timeout of the whole command")
+	format := fs.String("o", formatTable, "output format: table, json or yaml")
+	tenant := fs.String("tenant", "", "tenant to act on behalf of, client identity must be trusted by server")
+	fs.Usage = usage(fs)
+	fs.Parse(os.Args[1:])
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	out, err := newPrinter(os.Stdout, *format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	conn, err := opts.dial()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if *tenant != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-comment-tenant", *tenant)
+	}
+
+	err = cmd.run(ctx, pb.NewCommentClient(conn), fs.Args()[1:], out)
+	if err == errUsage {
+		fmt.Fprintf(os.Stderr, "Usage: commentctl [options] %s\n", cmd.usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		conn.Close()
+		os.Exit(1)
+	}
+}
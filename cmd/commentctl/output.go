@@ -0,0 +1,283 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/uuid"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// maxBodyWidth is the number of body characters shown in tables
+const maxBodyWidth = 60
+
+// printer writes results in one of the output formats
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return &printer{w, format}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func (p *printer) comments(comments []*pb.SingleComment) error {
+	if p.format != formatTable {
+		values := make([]interface{}, 0, len(comments))
+		for _, comment := range comments {
+			value, err := toValue(comment)
+			if err != nil {
+				return err
+			}
+
+			values = append(values, value)
+		}
+
+		return p.encode(values)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "UID\tRESOURCE\tPARENT\tUSER\tCREATED\tSTATE\tBODY")
+	for _, c := range comments {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.Uid, c.ResourceType+"/"+c.ResourceId, shortUID(c.ParentUid), author(c), formatTime(c.CreatedAt), state(c), shortBody(c.Body))
+	}
+
+	return tw.Flush()
+}
+
+func (p *printer) tree(nodes []*node) error {
+	if p.format != formatTable {
+		values, err := treeValues(nodes)
+		if err != nil {
+			return err
+		}
+
+		return p.encode(values)
+	}
+
+	var write func(nodes []*node, indent string) error
+	write = func(nodes []*node, indent string) error {
+		for _, n := range nodes {
+			c := n.comment
+			_, err := fmt.Fprintf(p.w, "%s%s  %s  %s  %s%s\n", indent, c.Uid, author(c), formatTime(c.CreatedAt), stateSuffix(c), shortBody(c.Body))
+			if err != nil {
+				return err
+			}
+
+			if err := write(n.replies, indent+"  "); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return write(nodes, "")
+}
+
+func (p *printer) records(records []*pb.UserDataRecord) error {
+	if p.format != formatTable {
+		values := make([]interface{}, 0, len(records))
+		for _, record := range records {
+			value, err := toValue(record)
+			if err != nil {
+				return err
+			}
+
+			values = append(values, value)
+		}
+
+		return p.encode(values)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "UID\tRESOURCE\tCREATED\tREVISIONS\tSTATE\tBODY")
+	for _, record := range records {
+		c := record.Comment
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", c.Uid, c.ResourceType+"/"+c.ResourceId, formatTime(c.CreatedAt), len(record.Revisions), state(c), shortBody(c.Body))
+	}
+
+	return tw.Flush()
+}
+
+func (p *printer) reasons(reasons []*pb.RemovalReason) error {
+	if p.format != formatTable {
+		values := make([]interface{}, 0, len(reasons))
+		for _, reason := range reasons {
+			value, err := toValue(reason)
+			if err != nil {
+				return err
+			}
+
+			values = append(values, value)
+		}
+
+		return p.encode(values)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CODE\tDESCRIPTION")
+	for _, reason := range reasons {
+		fmt.Fprintf(tw, "%s\t%s\n", reason.Code, reason.Description)
+	}
+
+	return tw.Flush()
+}
+
+func (p *printer) message(msg proto.Message) error {
+	value, err := toValue(msg)
+	if err != nil {
+		return err
+	}
+
+	if p.format != formatTable {
+		return p.encode(value)
+	}
+
+	fields, _ := value.(map[string]interface{})
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	for _, key := range keys {
+		fmt.Fprintf(tw, "%s:\t%v\n", key, fields[key])
+	}
+
+	return tw.Flush()
+}
+
+func (p *printer) encode(v interface{}) error {
+	if p.format == formatYAML {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		_, err = p.w.Write(data)
+		return err
+	}
+
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// toValue converts message to generic value keeping protobuf JSON field names and formats
+func toValue(msg proto.Message) (interface{}, error) {
+	m := jsonpb.Marshaler{EmitDefaults: true}
+	s, err := m.MarshalToString(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = json.Unmarshal([]byte(s), &value)
+	return value, err
+}
+
+func treeValues(nodes []*node) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(nodes))
+	for _, n := range nodes {
+		value, err := toValue(n.comment)
+		if err != nil {
+			return nil, err
+		}
+
+		replies, err := treeValues(n.replies)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := value.(map[string]interface{})
+		fields["replies"] = replies
+		values = append(values, fields)
+	}
+
+	return values, nil
+}
+
+func shortUID(uid string) string {
+	if uid == uuid.Nil.String() {
+		return "-"
+	}
+
+	return uid
+}
+
+func formatTime(ts *timestamp.Timestamp) string {
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return "-"
+	}
+
+	return t.Local().Format(time.RFC3339)
+}
+
+// author returns user UID of comment or display name of its guest author
+func author(c *pb.SingleComment) string {
+	if c.GuestName != "" {
+		return "guest:" + c.GuestName
+	}
+
+	return c.UserUid
+}
+
+func state(c *pb.SingleComment) string {
+	switch {
+	case c.IsDeleted && c.RemovedBy != pb.Remover_REMOVER_UNSPECIFIED:
+		s := "removed by " + strings.ToLower(c.RemovedBy.String())
+		if c.RemovalReason != "" {
+			s += ": " + c.RemovalReason
+		}
+
+		return s
+	case c.IsDeleted:
+		return "removed"
+	case c.IsPending:
+		return "pending"
+	default:
+		return "visible"
+	}
+}
+
+func stateSuffix(c *pb.SingleComment) string {
+	if s := state(c); s != "visible" {
+		return "[" + s + "]  "
+	}
+
+	return ""
+}
+
+// shortBody returns body on a single line truncated to maxBodyWidth characters
+func shortBody(body string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	if runes := []rune(body); len(runes) > maxBodyWidth {
+		return string(runes[:maxBodyWidth-3]) + "..."
+	}
+
+	return body
+}
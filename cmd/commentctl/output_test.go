@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/uuid"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func testComment(body string, deleted bool) *pb.SingleComment {
+	uid := uuid.New().String()
+	return &pb.SingleComment{Uid: uid, UserUid: uid, ResourceType: "post", ResourceId: uid, PostUid: uid, ParentUid: uuid.Nil.String(), Body: body, CreatedAt: ptypes.TimestampNow(), IsDeleted: deleted}
+}
+
+func TestPrinterTable(t *testing.T) {
+	var buf bytes.Buffer
+	p, _ := newPrinter(&buf, formatTable)
+	comment := testComment(strings.Repeat("long\nbody ", 20), true)
+	if err := p.comments([]*pb.SingleComment{comment}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("unexpected number of lines: got %v want %v", len(lines), 2)
+	}
+
+	if !strings.Contains(lines[1], comment.Uid) || !strings.Contains(lines[1], "removed") || !strings.HasSuffix(lines[1], "...") {
+		t.Errorf("unexpected row %q", lines[1])
+	}
+}
+
+func TestPrinterJSON(t *testing.T) {
+	var buf bytes.Buffer
+	p, _ := newPrinter(&buf, formatJSON)
+	comment := testComment("body", false)
+	if err := p.comments([]*pb.SingleComment{comment}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var result []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(result) != 1 || result[0]["uid"] != comment.Uid || result[0]["isDeleted"] != false {
+		t.Errorf("unexpected result %v", result)
+	}
+}
+
+func TestPrinterTreeYAML(t *testing.T) {
+	var buf bytes.Buffer
+	p, _ := newPrinter(&buf, formatYAML)
+	reply := testComment("reply", false)
+	nodes := []*node{{testComment("parent", false), []*node{{reply, nil}}}}
+	if err := p.tree(nodes); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var result []struct {
+		Body    string
+		Replies []struct {
+			UID string `yaml:"uid"`
+		}
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(result) != 1 || result[0].Body != "parent" || len(result[0].Replies) != 1 || result[0].Replies[0].UID != reply.Uid {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestNewPrinterUnknownFormat(t *testing.T) {
+	if _, err := newPrinter(&bytes.Buffer{}, "xml"); err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}
+
+func TestPrinterGuestComment(t *testing.T) {
+	var buf bytes.Buffer
+	p, _ := newPrinter(&buf, formatTable)
+	comment := testComment("body", false)
+	comment.GuestName, comment.IsPending = "guest", true
+	if err := p.comments([]*pb.SingleComment{comment}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if out := buf.String(); !strings.Contains(out, "guest:guest") || !strings.Contains(out, "pending") {
+		t.Errorf("expected guest author and pending state, got %q", out)
+	}
+}
+
+func TestPrinterRemovedComment(t *testing.T) {
+	var buf bytes.Buffer
+	p, _ := newPrinter(&buf, formatTable)
+	comment := testComment("[removed]", true)
+	comment.RemovedBy, comment.RemovalReason = pb.Remover_MODERATOR, "spam"
+	if err := p.comments([]*pb.SingleComment{comment}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if out := buf.String(); !strings.Contains(out, "removed by moderator: spam") {
+		t.Errorf("expected removal in state, got %q", out)
+	}
+}
+
+func TestPrinterReasons(t *testing.T) {
+	var buf bytes.Buffer
+	p, _ := newPrinter(&buf, formatTable)
+	reasons := []*pb.RemovalReason{{Code: "spam", Description: "Spam or advertising"}}
+	if err := p.reasons(reasons); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], "spam") || !strings.HasSuffix(lines[1], "Spam or advertising") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
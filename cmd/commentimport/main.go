@@ -0,0 +1,149 @@
+// commentimport imports comments from Disqus and WordPress exports.
+// Imported comments get UIDs derived from their IDs in the export, so an import can be safely run again.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/andreymgn/RSOI-comment/pkg/comment"
+)
+
+// options of an import
+type options struct {
+	source    string
+	tenant    string
+	mapping   string
+	report    string
+	conn      string
+	batchSize int
+	maxLength int
+	guests    bool
+	dryRun    bool
+}
+
+func usage(fs *flag.FlagSet) func() {
+	return func() {
+		fmt.Fprintf(fs.Output(), "Usage: commentimport -source disqus|wordpress -mapping FILE [options] EXPORT_FILE\n\n")
+		fmt.Fprintf(fs.Output(), "Mapping file is YAML with users and posts sections mapping identifiers of the source to UUIDs.\n")
+		fmt.Fprintf(fs.Output(), "Users are mapped by Disqus username, WordPress user ID or email, posts by Disqus thread identifier,\n")
+		fmt.Fprintf(fs.Output(), "WordPress post ID or link.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+}
+
+func main() {
+	var opts options
+	fs := flag.NewFlagSet("commentimport", flag.ExitOnError)
+	fs.StringVar(&opts.source, "source", "", "source of export: disqus or wordpress")
+	fs.StringVar(&opts.tenant, "tenant", "default", "tenant comments are imported to")
+	fs.StringVar(&opts.mapping, "mapping", "", "path to mapping file")
+	fs.StringVar(&opts.report, "report", "-", "path to CSV report of skipped comments, - writes it to stdout")
+	fs.StringVar(&opts.conn, "conn", os.Getenv("CONN"), "Postgres connection string, CONN environment variable by default")
+	fs.IntVar(&opts.batchSize, "batch-size", 500, "number of comments written in one transaction")
+	fs.IntVar(&opts.maxLength, "max-body-length", 10000, "maximum comment length in characters as configured on servers, 0 means unlimited")
+	fs.BoolVar(&opts.guests, "guests", false, "import comments of authors missing from mapping as guest comments instead of skipping them")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "only report what would be imported, don't connect to database")
+	fs.Usage = usage(fs)
+	fs.Parse(os.Args[1:])
+
+	_, known := parsers[opts.source]
+	if fs.NArg() != 1 || !known || opts.mapping == "" || opts.tenant == "" || opts.batchSize <= 0 || opts.maxLength < 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), opts, fs.Arg(0)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, opts options, path string) error {
+	m, err := loadMapping(opts.mapping)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	records, err := parsers[opts.source](f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	p := &planner{source: opts.source, tenant: opts.tenant, mapping: m, guests: opts.guests}
+	comments, skipped := p.plan(records)
+
+	var imported int64
+	if !opts.dryRun && len(comments) > 0 {
+		limits := comment.Limits{MaxBodyLength: opts.maxLength}
+		importer, err := comment.NewImporter(comment.DBConfig{ConnString: opts.conn, MaxOpenConns: 1}, limits)
+		if err != nil {
+			return err
+		}
+
+		defer importer.Close()
+
+		// parents are planned before replies, so every batch can refer to parents of the previous ones
+		for start := 0; start < len(comments); start += opts.batchSize {
+			end := start + opts.batchSize
+			if end > len(comments) {
+				end = len(comments)
+			}
+
+			nRows, err := importer.Import(ctx, opts.tenant, comments[start:end])
+			if err != nil {
+				return fmt.Errorf("importing comments %d-%d failed after %d were written: %v", start+1, end, imported, err)
+			}
+
+			imported += nRows
+		}
+	}
+
+	if err := writeReport(opts.report, skipped); err != nil {
+		return err
+	}
+
+	if opts.dryRun {
+		fmt.Fprintf(os.Stderr, "dry run: %d comments read, %d would be imported, %d skipped\n", len(records), len(comments), len(skipped))
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "%d comments read, %d imported, %d already imported, %d skipped\n",
+		len(records), imported, int64(len(comments))-imported, len(skipped))
+	return nil
+}
+
+// writeReport writes skipped comments as CSV sorted by reason
+func writeReport(path string, rows []skipped) error {
+	var w io.Writer = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+		w = f
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].reason < rows[j].reason })
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"id", "reason"})
+	for _, row := range rows {
+		cw.Write([]string{row.id, row.reason})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
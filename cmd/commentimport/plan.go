@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/andreymgn/RSOI-comment/pkg/comment"
+	"github.com/google/uuid"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// mapping maps identifiers of users and posts of the source to UUIDs of this service
+type mapping struct {
+	users map[string]uuid.UUID
+	posts map[string]uuid.UUID
+}
+
+type mappingFile struct {
+	Users map[string]string `yaml:"users"`
+	Posts map[string]string `yaml:"posts"`
+}
+
+func loadMapping(path string) (*mapping, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file mappingFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid mapping file %s: %v", path, err)
+	}
+
+	m := &mapping{make(map[string]uuid.UUID), make(map[string]uuid.UUID)}
+	for _, section := range []struct {
+		name   string
+		values map[string]string
+		result map[string]uuid.UUID
+	}{{"users", file.Users, m.users}, {"posts", file.Posts, m.posts}} {
+		for key, value := range section.values {
+			uid, err := uuid.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mapping file %s: %s %q maps to invalid UUID %q", path, section.name, key, value)
+			}
+
+			section.result[key] = uid
+		}
+	}
+
+	return m, nil
+}
+
+// lookup returns UUID of the first mapped key
+func lookup(values map[string]uuid.UUID, keys []string) (uuid.UUID, bool) {
+	for _, key := range keys {
+		if uid, ok := values[key]; ok {
+			return uid, true
+		}
+	}
+
+	return uuid.Nil, false
+}
+
+// skipped is a record which wasn't imported
+type skipped struct {
+	id     string
+	reason string
+}
+
+// planner turns records of an export into comments, replies are planned after their parents
+type planner struct {
+	source  string
+	tenant  string
+	mapping *mapping
+	// guests imports authors missing from mapping as guests instead of skipping their comments
+	guests bool
+
+	byID     map[string]*record
+	comments map[string]*comment.Comment
+	reasons  map[string]string
+	result   []*comment.Comment
+	skipped  []skipped
+}
+
+// plan returns comments to import in the order they must be imported and records which can't be imported
+func (p *planner) plan(records []*record) ([]*comment.Comment, []skipped) {
+	p.byID = make(map[string]*record, len(records))
+	p.comments = make(map[string]*comment.Comment, len(records))
+	p.reasons = make(map[string]string)
+	p.result, p.skipped = nil, nil
+
+	var unique []*record
+	for _, rec := range records {
+		if _, ok := p.byID[rec.id]; ok || rec.id == "" {
+			p.skipped = append(p.skipped, skipped{rec.id, "duplicate or missing ID"})
+			continue
+		}
+
+		p.byID[rec.id] = rec
+		unique = append(unique, rec)
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool { return unique[i].createdAt.Before(unique[j].createdAt) })
+	for _, rec := range unique {
+		p.visit(rec, make(map[string]bool))
+	}
+
+	return p.result, p.skipped
+}
+
+// visit plans record after its parent and returns its comment, nil if record is skipped
+func (p *planner) visit(rec *record, path map[string]bool) *comment.Comment {
+	if c, ok := p.comments[rec.id]; ok {
+		return c
+	}
+
+	if _, ok := p.reasons[rec.id]; ok {
+		return nil
+	}
+
+	if path[rec.id] {
+		return p.skip(rec, "reply cycle")
+	}
+
+	path[rec.id] = true
+	if rec.skip != "" {
+		return p.skip(rec, rec.skip)
+	}
+
+	postUID, ok := lookup(p.mapping.posts, rec.postKeys)
+	if !ok {
+		return p.skip(rec, "unknown post")
+	}
+
+	// sources may drop bodies of deleted comments, they are still imported as tombstones to keep their replies
+	if rec.body == "" && !rec.deleted {
+		return p.skip(rec, "empty body")
+	}
+
+	c := &comment.Comment{
+		UID:        comment.ImportUID(p.tenant, p.source, rec.id),
+		Target:     comment.Target{Type: "post", ID: postUID.String()},
+		Body:       rec.body,
+		ParentUID:  uuid.Nil,
+		CreatedAt:  rec.createdAt,
+		ModifiedAt: rec.createdAt,
+		IsDeleted:  rec.deleted,
+		IsPending:  rec.pending,
+	}
+
+	if rec.deleted {
+		c.Removal = comment.RemovedAtSource()
+	}
+
+	if rec.parentID != "" {
+		parentRec, ok := p.byID[rec.parentID]
+		if !ok {
+			return p.skip(rec, "parent not found")
+		}
+
+		parent := p.visit(parentRec, path)
+		switch {
+		case parent == nil:
+			return p.skip(rec, "parent skipped")
+		case parent.Target != c.Target:
+			return p.skip(rec, "parent belongs to another post")
+		}
+
+		c.ParentUID = parent.UID
+	}
+
+	if userUID, ok := lookup(p.mapping.users, rec.authorKeys); ok {
+		c.UserUID = userUID
+	} else if p.guests && rec.authorName != "" {
+		c.UserUID, c.GuestName = uuid.Nil, rec.authorName
+	} else if rec.deleted {
+		// removed comment is kept as a tombstone of erased author so its replies are kept too
+		c.UserUID, c.Body = comment.ErasedUserUID(), ""
+	} else {
+		return p.skip(rec, "unknown user")
+	}
+
+	p.comments[rec.id] = c
+	p.result = append(p.result, c)
+	return c
+}
+
+// skip records reason record isn't imported, only the first reason is reported
+func (p *planner) skip(rec *record, reason string) *comment.Comment {
+	if _, ok := p.reasons[rec.id]; ok {
+		return nil
+	}
+
+	p.reasons[rec.id] = reason
+	p.skipped = append(p.skipped, skipped{rec.id, reason})
+	return nil
+}
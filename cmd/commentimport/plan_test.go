@@ -0,0 +1,152 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/andreymgn/RSOI-comment/pkg/comment"
+	"github.com/google/uuid"
+)
+
+func testMapping() *mapping {
+	return &mapping{
+		users: map[string]uuid.UUID{"john": uuid.New()},
+		posts: map[string]uuid.UUID{"post-1": uuid.New(), "post-2": uuid.New()},
+	}
+}
+
+func testRecord(id, parentID, post, author string, minutes int) *record {
+	return &record{
+		id:         id,
+		parentID:   parentID,
+		postKeys:   []string{post},
+		authorKeys: []string{author},
+		authorName: author,
+		body:       "body of " + id,
+		createdAt:  time.Date(2012, 3, 5, 0, minutes, 0, 0, time.UTC),
+	}
+}
+
+func TestPlan(t *testing.T) {
+	m := testMapping()
+	records := []*record{
+		// reply precedes its parent in the export and even has an earlier date
+		testRecord("reply", "root", "post-1", "john", 0),
+		testRecord("root", "", "post-1", "john", 1),
+		testRecord("guest", "root", "post-1", "visitor", 2),
+		testRecord("root", "", "post-1", "john", 3),
+		testRecord("unknown-post", "", "post-3", "john", 4),
+		testRecord("orphan", "missing", "post-1", "john", 5),
+		testRecord("other-post", "root", "post-2", "john", 6),
+		testRecord("a", "b", "post-1", "john", 7),
+		testRecord("b", "a", "post-1", "john", 8),
+		testRecord("reply-to-guest", "guest", "post-1", "john", 9),
+	}
+
+	p := &planner{source: sourceDisqus, tenant: "blog", mapping: m}
+	comments, skipped := p.plan(records)
+	if len(comments) != 2 || comments[0].Body != "body of root" || comments[1].Body != "body of reply" {
+		t.Fatalf("expected root and its reply, got %v", comments)
+	}
+
+	root, reply := comments[0], comments[1]
+	if reply.ParentUID != root.UID || root.ParentUID != uuid.Nil || root.UserUID != m.users["john"] || root.Target.ID != m.posts["post-1"].String() {
+		t.Errorf("unexpected comments %+v, %+v", root, reply)
+	}
+
+	if !root.CreatedAt.Equal(records[1].createdAt) || !root.ModifiedAt.Equal(root.CreatedAt) {
+		t.Errorf("expected original timestamps, got %+v", root)
+	}
+
+	reasons := make(map[string]string)
+	for _, s := range skipped {
+		if _, ok := reasons[s.id]; ok {
+			t.Errorf("%s is reported twice", s.id)
+		}
+
+		reasons[s.id] = s.reason
+	}
+
+	expected := map[string]string{
+		"root":           "duplicate or missing ID",
+		"guest":          "unknown user",
+		"unknown-post":   "unknown post",
+		"orphan":         "parent not found",
+		"other-post":     "parent belongs to another post",
+		"a":              "reply cycle",
+		"b":              "parent skipped",
+		"reply-to-guest": "parent skipped",
+	}
+
+	for id, reason := range expected {
+		if reasons[id] != reason {
+			t.Errorf("%s: expected %q, got %q", id, reason, reasons[id])
+		}
+	}
+
+	p.guests = true
+	comments, _ = p.plan(records)
+	if len(comments) != 4 {
+		t.Fatalf("expected guest comment and reply to it, got %v", comments)
+	}
+
+	again, _ := (&planner{source: sourceDisqus, tenant: "blog", mapping: m}).plan(records)
+	if again[0].UID != root.UID {
+		t.Errorf("expected deterministic UIDs")
+	}
+}
+
+func TestPlanRemovedComment(t *testing.T) {
+	rec := testRecord("removed", "", "post-1", "john", 0)
+	rec.deleted = true
+	comments, _ := (&planner{source: sourceWordPress, tenant: "blog", mapping: testMapping()}).plan([]*record{rec})
+	if len(comments) != 1 || !comments[0].IsDeleted || comments[0].Removal.Reason == "" {
+		t.Errorf("expected removed comment with removal reason, got %v", comments)
+	}
+
+	rec.body = ""
+	reply := testRecord("reply", "removed", "post-1", "john", 1)
+	comments, skipped := (&planner{source: sourceDisqus, tenant: "blog", mapping: testMapping()}).plan([]*record{rec, reply})
+	if len(comments) != 2 || !comments[0].IsDeleted || comments[0].Body != "" || comments[1].ParentUID != comments[0].UID {
+		t.Errorf("expected tombstone with its reply, got %v, skipped %v", comments, skipped)
+	}
+
+	rec.authorKeys = []string{"unknown"}
+	comments, skipped = (&planner{source: sourceDisqus, tenant: "blog", mapping: testMapping()}).plan([]*record{rec, reply})
+	if len(comments) != 2 || comments[0].UserUID != comment.ErasedUserUID() || comments[1].ParentUID != comments[0].UID {
+		t.Errorf("expected tombstone of erased author with its reply, got %v, skipped %v", comments, skipped)
+	}
+
+	rec.deleted = false
+	if _, skipped := (&planner{source: sourceDisqus, tenant: "blog", mapping: testMapping()}).plan([]*record{rec}); len(skipped) != 1 {
+		t.Errorf("expected comment with empty body to be skipped, got %v", skipped)
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	f, err := ioutil.TempFile("", "mapping")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	defer os.Remove(f.Name())
+	userUID, postUID := uuid.New(), uuid.New()
+	f.WriteString("users:\n  john: " + userUID.String() + "\nposts:\n  post-1: " + postUID.String() + "\n")
+	f.Close()
+
+	m, err := loadMapping(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if m.users["john"] != userUID || m.posts["post-1"] != postUID {
+		t.Errorf("unexpected mapping %+v", m)
+	}
+
+	ioutil.WriteFile(f.Name(), []byte("users:\n  john: not-a-uuid\n"), 0600)
+	if _, err := loadMapping(f.Name()); err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	sourceDisqus    = "disqus"
+	sourceWordPress = "wordpress"
+)
+
+// wxrTimeLayout is the layout of comment dates in WordPress exports
+const wxrTimeLayout = "2006-01-02 15:04:05"
+
+// record is a comment read from an export
+type record struct {
+	id       string
+	parentID string
+	// postKeys and authorKeys identify post and author in mapping file, the first mapped key is used
+	postKeys   []string
+	authorKeys []string
+	authorName string
+	body       string
+	createdAt  time.Time
+	deleted    bool
+	pending    bool
+	// skip is the reason the record can't be imported, empty if it can
+	skip string
+}
+
+// parsers read records from exports of supported sources
+var parsers = map[string]func(io.Reader) ([]*record, error){
+	sourceDisqus:    parseDisqus,
+	sourceWordPress: parseWXR,
+}
+
+// Disqus exports name threads and posts with dsq:id attributes and refer to them by the same attributes.
+// Tags without namespace match elements and attributes of any namespace.
+type disqusRef struct {
+	DsqID string `xml:"id,attr"`
+}
+
+type disqusThread struct {
+	DsqID      string `xml:"id,attr"`
+	Identifier string `xml:"id"`
+	Link       string `xml:"link"`
+}
+
+type disqusPost struct {
+	DsqID     string `xml:"id,attr"`
+	Message   string `xml:"message"`
+	CreatedAt string `xml:"createdAt"`
+	IsDeleted bool   `xml:"isDeleted"`
+	IsSpam    bool   `xml:"isSpam"`
+	Author    struct {
+		Name        string `xml:"name"`
+		Email       string `xml:"email"`
+		Username    string `xml:"username"`
+		IsAnonymous bool   `xml:"isAnonymous"`
+	} `xml:"author"`
+	Thread disqusRef  `xml:"thread"`
+	Parent *disqusRef `xml:"parent"`
+}
+
+type disqusExport struct {
+	Threads []disqusThread `xml:"thread"`
+	Posts   []disqusPost   `xml:"post"`
+}
+
+// parseDisqus reads Disqus XML export, posts are mapped by thread identifier or link, authors by username or email
+func parseDisqus(r io.Reader) ([]*record, error) {
+	var export disqusExport
+	if err := xml.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("invalid Disqus export: %v", err)
+	}
+
+	threads := make(map[string]disqusThread, len(export.Threads))
+	for _, thread := range export.Threads {
+		threads[thread.DsqID] = thread
+	}
+
+	records := make([]*record, 0, len(export.Posts))
+	for _, post := range export.Posts {
+		rec := &record{
+			id:         post.DsqID,
+			authorName: strings.TrimSpace(post.Author.Name),
+			body:       strings.TrimSpace(post.Message),
+			deleted:    post.IsDeleted,
+		}
+
+		if post.Parent != nil {
+			rec.parentID = post.Parent.DsqID
+		}
+
+		if thread, ok := threads[post.Thread.DsqID]; ok {
+			rec.postKeys = nonEmpty(strings.TrimSpace(thread.Identifier), strings.TrimSpace(thread.Link))
+		}
+
+		if !post.Author.IsAnonymous {
+			rec.authorKeys = nonEmpty(strings.TrimSpace(post.Author.Username))
+		}
+
+		rec.authorKeys = append(rec.authorKeys, nonEmpty(strings.ToLower(strings.TrimSpace(post.Author.Email)))...)
+
+		createdAt, err := time.Parse(time.RFC3339, strings.TrimSpace(post.CreatedAt))
+		rec.createdAt = createdAt
+		switch {
+		case post.IsSpam:
+			rec.skip = "spam"
+		case err != nil:
+			rec.skip = "invalid date"
+		}
+
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+type wxrComment struct {
+	ID          string `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	Date        string `xml:"comment_date"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	Parent      string `xml:"comment_parent"`
+	UserID      string `xml:"comment_user_id"`
+}
+
+type wxrItem struct {
+	PostID   string       `xml:"post_id"`
+	Link     string       `xml:"link"`
+	Comments []wxrComment `xml:"comment"`
+}
+
+type wxrExport struct {
+	Items []wxrItem `xml:"channel>item"`
+}
+
+// parseWXR reads WordPress WXR export, posts are mapped by post ID or link, authors by user ID or email.
+// Comment IDs are unique within the export only, so they are prefixed with post ID.
+func parseWXR(r io.Reader) ([]*record, error) {
+	var export wxrExport
+	if err := xml.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("invalid WordPress export: %v", err)
+	}
+
+	var records []*record
+	for _, item := range export.Items {
+		postID := strings.TrimSpace(item.PostID)
+		for _, comment := range item.Comments {
+			rec := &record{
+				id:         postID + "/" + strings.TrimSpace(comment.ID),
+				postKeys:   nonEmpty(postID, strings.TrimSpace(item.Link)),
+				authorName: strings.TrimSpace(comment.Author),
+				body:       strings.TrimSpace(comment.Content),
+			}
+
+			if parent := strings.TrimSpace(comment.Parent); parent != "" && parent != "0" {
+				rec.parentID = postID + "/" + parent
+			}
+
+			if userID := strings.TrimSpace(comment.UserID); userID != "" && userID != "0" {
+				rec.authorKeys = []string{userID}
+			}
+
+			rec.authorKeys = append(rec.authorKeys, nonEmpty(strings.ToLower(strings.TrimSpace(comment.AuthorEmail)))...)
+
+			// dates before GMT dates were recorded are zero, local date is the best guess then
+			date := strings.TrimSpace(comment.DateGMT)
+			if date == "" || strings.HasPrefix(date, "0000") {
+				date = strings.TrimSpace(comment.Date)
+			}
+
+			createdAt, err := time.Parse(wxrTimeLayout, date)
+			rec.createdAt = createdAt
+			switch approved := strings.TrimSpace(comment.Approved); {
+			case comment.Type == "pingback" || comment.Type == "trackback":
+				rec.skip = comment.Type
+			case approved == "spam":
+				rec.skip = "spam"
+			case approved == "trash" || approved == "post-trashed":
+				rec.deleted = true
+			case approved == "0":
+				rec.pending = true
+			}
+
+			if err != nil && rec.skip == "" {
+				rec.skip = "invalid date"
+			}
+
+			records = append(records, rec)
+		}
+	}
+
+	return records, nil
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
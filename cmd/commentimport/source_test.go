@@ -0,0 +1,143 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const disqusExample = `<?xml version="1.0" encoding="utf-8"?>
+<disqus xmlns="http://disqus.com" xmlns:dsq="http://disqus.com/disqus-internals">
+  <thread dsq:id="100">
+    <id>post-1</id>
+    <link>https://blog.example.com/post-1/</link>
+  </thread>
+  <post dsq:id="1">
+    <message><![CDATA[<p>First</p>]]></message>
+    <createdAt>2012-03-05T16:51:55Z</createdAt>
+    <isDeleted>false</isDeleted>
+    <isSpam>false</isSpam>
+    <author>
+      <email>John@Example.com</email>
+      <name>John</name>
+      <isAnonymous>false</isAnonymous>
+      <username>john</username>
+    </author>
+    <thread dsq:id="100" />
+  </post>
+  <post dsq:id="2">
+    <message>Reply</message>
+    <createdAt>2012-03-06T10:00:00Z</createdAt>
+    <isDeleted>true</isDeleted>
+    <isSpam>false</isSpam>
+    <author>
+      <name>Guest</name>
+      <isAnonymous>true</isAnonymous>
+    </author>
+    <thread dsq:id="100" />
+    <parent dsq:id="1" />
+  </post>
+  <post dsq:id="3">
+    <message>Buy now</message>
+    <createdAt>2012-03-07T10:00:00Z</createdAt>
+    <isSpam>true</isSpam>
+    <thread dsq:id="100" />
+  </post>
+</disqus>`
+
+const wxrExample = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0" xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+  <item>
+    <link>https://blog.example.com/?p=7</link>
+    <wp:post_id>7</wp:post_id>
+    <wp:comment>
+      <wp:comment_id>10</wp:comment_id>
+      <wp:comment_author><![CDATA[Jane]]></wp:comment_author>
+      <wp:comment_author_email>jane@example.com</wp:comment_author_email>
+      <wp:comment_date>2011-01-02 17:04:05</wp:comment_date>
+      <wp:comment_date_gmt>2011-01-02 15:04:05</wp:comment_date_gmt>
+      <wp:comment_content><![CDATA[Hello]]></wp:comment_content>
+      <wp:comment_approved>1</wp:comment_approved>
+      <wp:comment_type></wp:comment_type>
+      <wp:comment_parent>0</wp:comment_parent>
+      <wp:comment_user_id>3</wp:comment_user_id>
+    </wp:comment>
+    <wp:comment>
+      <wp:comment_id>11</wp:comment_id>
+      <wp:comment_author><![CDATA[Visitor]]></wp:comment_author>
+      <wp:comment_date>2011-01-03 12:00:00</wp:comment_date>
+      <wp:comment_date_gmt>0000-00-00 00:00:00</wp:comment_date_gmt>
+      <wp:comment_content><![CDATA[Hi Jane]]></wp:comment_content>
+      <wp:comment_approved>0</wp:comment_approved>
+      <wp:comment_parent>10</wp:comment_parent>
+      <wp:comment_user_id>0</wp:comment_user_id>
+    </wp:comment>
+    <wp:comment>
+      <wp:comment_id>12</wp:comment_id>
+      <wp:comment_date_gmt>2011-01-04 12:00:00</wp:comment_date_gmt>
+      <wp:comment_content><![CDATA[Linked]]></wp:comment_content>
+      <wp:comment_approved>1</wp:comment_approved>
+      <wp:comment_type>pingback</wp:comment_type>
+    </wp:comment>
+  </item>
+</channel>
+</rss>`
+
+func TestParseDisqus(t *testing.T) {
+	records, err := parseDisqus(strings.NewReader(disqusExample))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+
+	first, reply, spam := records[0], records[1], records[2]
+	if first.id != "1" || first.body != "<p>First</p>" || strings.Join(first.postKeys, " ") != "post-1 https://blog.example.com/post-1/" ||
+		strings.Join(first.authorKeys, " ") != "john john@example.com" || !first.createdAt.Equal(time.Date(2012, 3, 5, 16, 51, 55, 0, time.UTC)) {
+		t.Errorf("unexpected record %+v", first)
+	}
+
+	if reply.parentID != "1" || !reply.deleted || len(reply.authorKeys) != 0 || reply.authorName != "Guest" {
+		t.Errorf("unexpected reply %+v", reply)
+	}
+
+	if spam.skip != "spam" {
+		t.Errorf("expected spam to be skipped, got %+v", spam)
+	}
+}
+
+func TestParseWXR(t *testing.T) {
+	records, err := parseWXR(strings.NewReader(wxrExample))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+
+	first, reply, pingback := records[0], records[1], records[2]
+	if first.id != "7/10" || first.parentID != "" || strings.Join(first.postKeys, " ") != "7 https://blog.example.com/?p=7" ||
+		strings.Join(first.authorKeys, " ") != "3 jane@example.com" || !first.createdAt.Equal(time.Date(2011, 1, 2, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected record %+v", first)
+	}
+
+	if reply.parentID != "7/10" || !reply.pending || len(reply.authorKeys) != 0 || !reply.createdAt.Equal(time.Date(2011, 1, 3, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected reply %+v", reply)
+	}
+
+	if pingback.skip != "pingback" {
+		t.Errorf("expected pingback to be skipped, got %+v", pingback)
+	}
+}
+
+func TestParseInvalidExport(t *testing.T) {
+	for source, parse := range parsers {
+		if _, err := parse(strings.NewReader("<disqus>")); err == nil {
+			t.Errorf("%s: expected error, got nothing", source)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package comment
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// anyMethod matches every method in authorization policy
+	anyMethod = "*"
+	// callerHeader names identity of the HTTP client gateway calls on behalf of
+	callerHeader = "x-comment-caller"
+	// anonymousIdentity is identity of gateway clients without client certificate
+	anonymousIdentity = "anonymous"
+)
+
+var (
+	statusNoIdentity       = status.Error(codes.Unauthenticated, "client certificate required")
+	statusPermissionDenied = status.Error(codes.PermissionDenied, "method not allowed for caller")
+)
+
+type identityKey struct{}
+
+// AuthConfig describes which services may call which methods
+type AuthConfig struct {
+	// Identities maps client certificate names (URI or DNS SAN, subject common name) to service identities.
+	// If empty, subject common name is used as identity.
+	Identities map[string]string
+	// Policy maps method name or "*" to identities allowed to call it.
+	// If empty, authorization is disabled.
+	Policy map[string][]string
+	// Gateway is identity of the HTTP gateway. Its calls are made on behalf of HTTP clients,
+	// so they get identity of the client certificate the gateway names in metadata, or "anonymous".
+	Gateway string
+}
+
+// ParseIdentities parses comma separated list of name=identity pairs
+func ParseIdentities(s string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid identity mapping %q", pair)
+		}
+
+		result[parts[0]] = parts[1]
+	}
+
+	return result, nil
+}
+
+// ParsePolicy parses semicolon separated list of method=identity,identity rules
+func ParsePolicy(s string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		parts := strings.SplitN(rule, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid policy rule %q", rule)
+		}
+
+		for _, identity := range strings.Split(parts[1], ",") {
+			if identity = strings.TrimSpace(identity); identity != "" {
+				result[parts[0]] = append(result[parts[0]], identity)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// IdentityFromContext returns identity of the calling service
+func IdentityFromContext(ctx context.Context) (string, bool) {
+	identity, ok := ctx.Value(identityKey{}).(string)
+	return identity, ok
+}
+
+// identity resolves service identity from verified client certificate
+func (c AuthConfig) identity(cert *x509.Certificate) (string, bool) {
+	if len(c.Identities) == 0 {
+		return cert.Subject.CommonName, cert.Subject.CommonName != ""
+	}
+
+	var names []string
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+
+	names = append(names, cert.DNSNames...)
+	names = append(names, cert.Subject.CommonName)
+	for _, name := range names {
+		if identity, ok := c.Identities[name]; ok {
+			return identity, true
+		}
+	}
+
+	return "", false
+}
+
+// allowed reports whether identity may call method
+func (c AuthConfig) allowed(identity, method string) bool {
+	for _, rule := range []string{method, anyMethod} {
+		for _, allowed := range c.Policy[rule] {
+			if allowed == identity {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// authorize stores caller identity in context and checks it against policy
+func (c AuthConfig) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	var identity string
+	var ok bool
+	if p, hasPeer := peer.FromContext(ctx); hasPeer {
+		if tlsInfo, isTLS := p.AuthInfo.(credentials.TLSInfo); isTLS && len(tlsInfo.State.VerifiedChains) > 0 {
+			identity, ok = c.identity(tlsInfo.State.VerifiedChains[0][0])
+		}
+	}
+
+	if ok && c.Gateway != "" && identity == c.Gateway {
+		identity = anonymousIdentity
+		if md, hasMD := metadata.FromIncomingContext(ctx); hasMD {
+			if values := md.Get(callerHeader); len(values) > 0 && values[0] != "" {
+				identity = values[0]
+			}
+		}
+	}
+
+	if ok {
+		ctx = context.WithValue(ctx, identityKey{}, identity)
+	}
+
+	if len(c.Policy) == 0 {
+		return ctx, nil
+	}
+
+	if !ok {
+		return nil, statusNoIdentity
+	}
+
+	if !c.allowed(identity, methodName(fullMethod)) {
+		return nil, statusPermissionDenied
+	}
+
+	return ctx, nil
+}
+
+func (c AuthConfig) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := c.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (c AuthConfig) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := c.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{stream, ctx})
+	}
+}
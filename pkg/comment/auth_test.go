@@ -0,0 +1,177 @@
+package comment
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const deleteMethod = "/comment.Comment/DeleteComment"
+
+func peerContext(cert *x509.Certificate) context.Context {
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("DeleteComment=moderation; *=gateway, moderation")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(policy["DeleteComment"]) != 1 || len(policy[anyMethod]) != 2 {
+		t.Errorf("unexpected policy %v", policy)
+	}
+
+	_, err = ParsePolicy("DeleteComment")
+	if err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}
+
+func TestParseIdentities(t *testing.T) {
+	identities, err := ParseIdentities("gateway.internal=gateway,spiffe://rsoi/moderation=moderation")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if identities["spiffe://rsoi/moderation"] != "moderation" {
+		t.Errorf("unexpected identities %v", identities)
+	}
+
+	_, err = ParseIdentities("gateway")
+	if err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	moderationURI, _ := url.Parse("spiffe://rsoi/moderation")
+	gateway := &x509.Certificate{Subject: pkix.Name{CommonName: "gateway"}, DNSNames: []string{"gateway.internal"}}
+	moderation := &x509.Certificate{Subject: pkix.Name{CommonName: "moderation"}, URIs: []*url.URL{moderationURI}}
+
+	c := AuthConfig{
+		Identities: map[string]string{"gateway.internal": "gw", "spiffe://rsoi/moderation": "mod"},
+		Policy:     map[string][]string{"DeleteComment": {"mod"}, anyMethod: {"gw"}},
+	}
+
+	ctx, err := c.authorize(peerContext(moderation), deleteMethod)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if identity, _ := IdentityFromContext(ctx); identity != "mod" {
+		t.Errorf("unexpected identity: got %v want %v", identity, "mod")
+	}
+
+	_, err = c.authorize(peerContext(moderation), "/comment.Comment/CreateComment")
+	if err != statusPermissionDenied {
+		t.Errorf("unexpected error: got %v want %v", err, statusPermissionDenied)
+	}
+
+	_, err = c.authorize(peerContext(gateway), deleteMethod)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	_, err = c.authorize(context.Background(), deleteMethod)
+	if err != statusNoIdentity {
+		t.Errorf("unexpected error: got %v want %v", err, statusNoIdentity)
+	}
+}
+
+func TestAuthorizeGatewayCaller(t *testing.T) {
+	gateway := &x509.Certificate{Subject: pkix.Name{CommonName: "gateway"}}
+	c := AuthConfig{Policy: map[string][]string{"GetComment": {anonymousIdentity, "web"}, "DeleteComment": {"mod"}}, Gateway: "gateway"}
+
+	ctx, err := c.authorize(peerContext(gateway), "/comment.Comment/GetComment")
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if identity, _ := IdentityFromContext(ctx); identity != anonymousIdentity {
+		t.Errorf("unexpected identity: got %v want %v", identity, anonymousIdentity)
+	}
+
+	_, err = c.authorize(peerContext(gateway), deleteMethod)
+	if err != statusPermissionDenied {
+		t.Errorf("unexpected error: got %v want %v", err, statusPermissionDenied)
+	}
+
+	ctx = metadata.NewIncomingContext(peerContext(gateway), metadata.Pairs(callerHeader, "mod"))
+	ctx, err = c.authorize(ctx, deleteMethod)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if identity, _ := IdentityFromContext(ctx); identity != "mod" {
+		t.Errorf("unexpected identity: got %v want %v", identity, "mod")
+	}
+
+	web := &x509.Certificate{Subject: pkix.Name{CommonName: "web"}}
+	ctx = metadata.NewIncomingContext(peerContext(web), metadata.Pairs(callerHeader, "mod"))
+	ctx, err = c.authorize(ctx, "/comment.Comment/GetComment")
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if identity, _ := IdentityFromContext(ctx); identity != "web" {
+		t.Errorf("caller header of non-gateway peer is honored: got %v want %v", identity, "web")
+	}
+}
+
+func TestAuthorizeDisabled(t *testing.T) {
+	c := AuthConfig{}
+	gateway := &x509.Certificate{Subject: pkix.Name{CommonName: "gateway"}}
+
+	ctx, err := c.authorize(peerContext(gateway), deleteMethod)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if identity, _ := IdentityFromContext(ctx); identity != "gateway" {
+		t.Errorf("unexpected identity: got %v want %v", identity, "gateway")
+	}
+
+	_, err = c.authorize(context.Background(), deleteMethod)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestChainUnaryServer(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return nil, nil
+	}
+
+	chained := chainUnaryServer(interceptor("first"), interceptor("second"))
+	chained(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: deleteMethod}, handler)
+	if len(calls) != 3 || calls[0] != "first" || calls[1] != "second" || calls[2] != "handler" {
+		t.Errorf("unexpected call order %v", calls)
+	}
+}
+
+func TestExceptHealth(t *testing.T) {
+	c := AuthConfig{Policy: map[string][]string{anyMethod: {"gw"}}}
+	interceptor := exceptHealthUnary(c.unaryInterceptor())
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	if _, err := interceptor(context.Background(), nil, info, handler); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	info.FullMethod = deleteMethod
+	if _, err := interceptor(context.Background(), nil, info, handler); err != statusNoIdentity {
+		t.Errorf("unexpected error: got %v want %v", err, statusNoIdentity)
+	}
+}
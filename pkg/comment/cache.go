@@ -0,0 +1,338 @@
+package comment
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+)
+
+// CacheConfig describes in-process cache of comments, zero Size disables cache
+type CacheConfig struct {
+	// Size is the maximum number of cached datastore results
+	Size int
+	// TTL limits how long result is cached, it bounds staleness of changes made by other instances
+	TTL time.Duration
+}
+
+// Validate checks that cache config is consistent
+func (c CacheConfig) Validate() error {
+	if c.Size < 0 || c.TTL < 0 {
+		return errors.New("cache settings must not be negative")
+	}
+
+	if c.Size > 0 && c.TTL == 0 {
+		return errors.New("cache TTL is required when cache is enabled")
+	}
+
+	return nil
+}
+
+// cacheEntry is a cached result of getAll or getOne
+type cacheEntry struct {
+	key     string
+	target  Target
+	value   interface{}
+	expires time.Time
+}
+
+// targetEntries are cached results of a target and comments seen in them
+type targetEntries struct {
+	elems    map[*list.Element]struct{}
+	comments map[uuid.UUID]struct{}
+}
+
+// lruCache is a size limited cache evicting least recently used entries.
+// Entries are indexed by target so all results of a target can be invalidated at once.
+type lruCache struct {
+	size int
+	ttl  time.Duration
+
+	mu       sync.Mutex
+	entries  *list.List
+	byKey    map[string]*list.Element
+	byTarget map[Target]*targetEntries
+	targets  map[uuid.UUID]Target
+}
+
+func newLRUCache(size int, ttl time.Duration) *lruCache {
+	c := &lruCache{size: size, ttl: ttl}
+	c.purge()
+	return c
+}
+
+func (c *lruCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.byKey[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.entries.MoveToFront(elem)
+	return entry.value, true
+}
+
+// add caches value of target and remembers target of every comment in comments
+func (c *lruCache) add(key string, target Target, value interface{}, comments ...*Comment) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.byKey[key]; ok {
+		c.remove(elem)
+	}
+
+	elem := c.entries.PushFront(&cacheEntry{key, target, value, time.Now().Add(c.ttl)})
+	c.byKey[key] = elem
+	entries, ok := c.byTarget[target]
+	if !ok {
+		entries = &targetEntries{make(map[*list.Element]struct{}), make(map[uuid.UUID]struct{})}
+		c.byTarget[target] = entries
+	}
+
+	entries.elems[elem] = struct{}{}
+	for _, comment := range comments {
+		entries.comments[comment.UID] = struct{}{}
+		c.targets[comment.UID] = target
+	}
+
+	for c.entries.Len() > c.size {
+		c.remove(c.entries.Back())
+		cacheEvictions.Inc()
+	}
+
+	cacheEntries.Set(float64(c.entries.Len()))
+}
+
+// targetOf returns target of comment if it was seen in cached results
+func (c *lruCache) targetOf(uid uuid.UUID) (Target, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	target, ok := c.targets[uid]
+	return target, ok
+}
+
+// invalidateTarget removes all cached results of target
+func (c *lruCache) invalidateTarget(target Target) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entries, ok := c.byTarget[target]; ok {
+		for elem := range entries.elems {
+			c.remove(elem)
+		}
+	}
+
+	cacheEntries.Set(float64(c.entries.Len()))
+}
+
+// purge removes all cached results
+func (c *lruCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = list.New()
+	c.byKey = make(map[string]*list.Element)
+	c.byTarget = make(map[Target]*targetEntries)
+	c.targets = make(map[uuid.UUID]Target)
+	cacheEntries.Set(0)
+}
+
+// remove must be called with mu held
+func (c *lruCache) remove(elem *list.Element) {
+	entry := c.entries.Remove(elem).(*cacheEntry)
+	delete(c.byKey, entry.key)
+
+	entries := c.byTarget[entry.target]
+	delete(entries.elems, elem)
+	if len(entries.elems) == 0 {
+		for uid := range entries.comments {
+			delete(c.targets, uid)
+		}
+
+		delete(c.byTarget, entry.target)
+	}
+}
+
+// cachedDatastore caches results of getAll and getOne, mutations invalidate results of affected target.
+// Keys include tenant, so results are never shared between tenants.
+// Cached comments are shared between callers and must not be modified.
+// Callers pinned to primary are never served from cache: it may have been refilled from a lagging replica
+// after their write, their fresh results are cached though.
+type cachedDatastore struct {
+	next  datastore
+	cache *lruCache
+}
+
+func newCachedDatastore(next datastore, conf CacheConfig) cachedDatastore {
+	return cachedDatastore{next, newLRUCache(conf.Size, conf.TTL)}
+}
+
+func (ds cachedDatastore) getAll(ctx context.Context, target Target, parentUID uuid.UUID, pageSize, pageNumber int32) ([]*Comment, error) {
+	key := fmt.Sprintf("all:%q:%q:%q:%s:%d:%d", TenantFromContext(ctx), target.Type, target.ID, parentUID, pageSize, pageNumber)
+	if value, ok := ds.cache.get(key); ok && !usePrimary(ctx) {
+		cacheHits.WithLabelValues("getAll").Inc()
+		return value.([]*Comment), nil
+	}
+
+	cacheMisses.WithLabelValues("getAll").Inc()
+	comments, err := ds.next.getAll(ctx, target, parentUID, pageSize, pageNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	ds.cache.add(key, target, comments, comments...)
+	return comments, nil
+}
+
+func (ds cachedDatastore) getOne(ctx context.Context, uid uuid.UUID) (*Comment, error) {
+	key := fmt.Sprintf("one:%q:%s", TenantFromContext(ctx), uid)
+	if value, ok := ds.cache.get(key); ok && !usePrimary(ctx) {
+		cacheHits.WithLabelValues("getOne").Inc()
+		return value.(*Comment), nil
+	}
+
+	cacheMisses.WithLabelValues("getOne").Inc()
+	comment, err := ds.next.getOne(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	ds.cache.add(key, comment.Target, comment, comment)
+	return comment, nil
+}
+
+// invalidateComment looks up target of comment and returns function removing cached results of the target.
+// Target is looked up before the comment is changed, so it is known even if the comment gets deleted.
+func (ds cachedDatastore) invalidateComment(ctx context.Context, uid uuid.UUID) func() {
+	target, ok := ds.cache.targetOf(uid)
+	if !ok {
+		comment, err := ds.next.getOne(ctx, uid)
+		if err != nil {
+			// comment doesn't exist, so nothing can be cached for it
+			return func() {}
+		}
+
+		target = comment.Target
+	}
+
+	return func() { ds.cache.invalidateTarget(target) }
+}
+
+func (ds cachedDatastore) create(ctx context.Context, target Target, body string, parentUID, userUID uuid.UUID, guest *Guest) (*Comment, error) {
+	defer ds.cache.invalidateTarget(target)
+	return ds.next.create(ctx, target, body, parentUID, userUID, guest)
+}
+
+func (ds cachedDatastore) update(ctx context.Context, uid uuid.UUID, changes commentChanges) (*Comment, error) {
+	defer ds.invalidateComment(ctx, uid)()
+	return ds.next.update(ctx, uid, changes)
+}
+
+func (ds cachedDatastore) removeContent(ctx context.Context, uid uuid.UUID, removal Removal) error {
+	defer ds.invalidateComment(ctx, uid)()
+	return ds.next.removeContent(ctx, uid, removal)
+}
+
+func (ds cachedDatastore) restoreContent(ctx context.Context, uid uuid.UUID) error {
+	defer ds.invalidateComment(ctx, uid)()
+	return ds.next.restoreContent(ctx, uid)
+}
+
+func (ds cachedDatastore) delete(ctx context.Context, uid uuid.UUID, mode deleteMode, removal Removal) (int64, error) {
+	defer ds.invalidateComment(ctx, uid)()
+	return ds.next.delete(ctx, uid, mode, removal)
+}
+
+func (ds cachedDatastore) getOwner(ctx context.Context, uid uuid.UUID) (string, error) {
+	return ds.next.getOwner(ctx, uid)
+}
+
+func (ds cachedDatastore) getAllByUser(ctx context.Context, userUID uuid.UUID) ([]*Comment, error) {
+	return ds.next.getAllByUser(ctx, userUID)
+}
+
+func (ds cachedDatastore) getRevisionsByUser(ctx context.Context, userUID uuid.UUID) ([]*Revision, error) {
+	return ds.next.getRevisionsByUser(ctx, userUID)
+}
+
+// eraseUser affects comments of many targets, so whole cache is purged
+func (ds cachedDatastore) eraseUser(ctx context.Context, userUID uuid.UUID) (int64, error) {
+	defer ds.cache.purge()
+	return ds.next.eraseUser(ctx, userUID)
+}
+
+func (ds cachedDatastore) deleteForTarget(ctx context.Context, target Target, batchSize int32) (int64, error) {
+	defer ds.cache.invalidateTarget(target)
+	return ds.next.deleteForTarget(ctx, target, batchSize)
+}
+
+func (ds cachedDatastore) removeContentForTarget(ctx context.Context, target Target, batchSize int32) (int64, error) {
+	defer ds.cache.invalidateTarget(target)
+	return ds.next.removeContentForTarget(ctx, target, batchSize)
+}
+
+func (ds cachedDatastore) getPending(ctx context.Context, pageSize, pageNumber int32) ([]*Comment, error) {
+	return ds.next.getPending(ctx, pageSize, pageNumber)
+}
+
+func (ds cachedDatastore) getRemoved(ctx context.Context, reason string, pageSize, pageNumber int32) ([]*Comment, error) {
+	return ds.next.getRemoved(ctx, reason, pageSize, pageNumber)
+}
+
+func (ds cachedDatastore) approve(ctx context.Context, uid uuid.UUID) (*Comment, error) {
+	defer ds.invalidateComment(ctx, uid)()
+	return ds.next.approve(ctx, uid)
+}
+
+// claimGuest affects comments of many targets, so whole cache is purged
+func (ds cachedDatastore) claimGuest(ctx context.Context, emailHash string, userUID uuid.UUID) (int64, error) {
+	defer ds.cache.purge()
+	return ds.next.claimGuest(ctx, emailHash, userUID)
+}
+
+func (ds cachedDatastore) importComments(ctx context.Context, comments []*Comment) (int64, error) {
+	defer func() {
+		for _, c := range comments {
+			ds.cache.invalidateTarget(c.Target)
+		}
+	}()
+
+	return ds.next.importComments(ctx, comments)
+}
+
+// drafts are not cached, they are read by their author only
+func (ds cachedDatastore) saveDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID, body string, notBefore time.Time) (*Draft, error) {
+	return ds.next.saveDraft(ctx, userUID, target, parentUID, body, notBefore)
+}
+
+func (ds cachedDatastore) getDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID, notBefore time.Time) (*Draft, error) {
+	return ds.next.getDraft(ctx, userUID, target, parentUID, notBefore)
+}
+
+func (ds cachedDatastore) getDrafts(ctx context.Context, userUID uuid.UUID, notBefore time.Time, pageSize, pageNumber int32) ([]*Draft, error) {
+	return ds.next.getDrafts(ctx, userUID, notBefore, pageSize, pageNumber)
+}
+
+func (ds cachedDatastore) deleteDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID) error {
+	return ds.next.deleteDraft(ctx, userUID, target, parentUID)
+}
+
+func (ds cachedDatastore) ping(ctx context.Context) error {
+	return ds.next.ping(ctx)
+}
+
+func (ds cachedDatastore) close() error {
+	return ds.next.close()
+}
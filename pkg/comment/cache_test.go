@@ -0,0 +1,141 @@
+package comment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+)
+
+// countingdb counts reads reaching the datastore
+type countingdb struct {
+	*mockdb
+	getAllCalls, getOneCalls int
+}
+
+func (cdb *countingdb) getAll(ctx context.Context, target Target, parentUID uuid.UUID, pageSize, pageNumber int32) ([]*Comment, error) {
+	cdb.getAllCalls++
+	return cdb.mockdb.getAll(ctx, target, parentUID, pageSize, pageNumber)
+}
+
+func (cdb *countingdb) getOne(ctx context.Context, uid uuid.UUID) (*Comment, error) {
+	cdb.getOneCalls++
+	return cdb.mockdb.getOne(ctx, uid)
+}
+
+func TestCachedGetAll(t *testing.T) {
+	cdb := &countingdb{mockdb: &mockdb{}}
+	ds := newCachedDatastore(cdb, CacheConfig{Size: 10, TTL: time.Minute})
+	target := postTarget(uuid.New())
+
+	for i := 0; i < 2; i++ {
+		if _, err := ds.getAll(context.Background(), target, uuid.Nil, 10, 0); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if cdb.getAllCalls != 1 {
+		t.Errorf("unexpected number of datastore calls: got %v want %v", cdb.getAllCalls, 1)
+	}
+
+	ds.getAll(context.Background(), target, uuid.Nil, 10, 1)
+	if cdb.getAllCalls != 2 {
+		t.Errorf("other page served from cache")
+	}
+
+	ds.create(context.Background(), target, "body", uuid.Nil, uuid.New(), nil)
+	ds.getAll(context.Background(), target, uuid.Nil, 10, 0)
+	if cdb.getAllCalls != 3 {
+		t.Errorf("create did not invalidate cache")
+	}
+
+	ds.importComments(context.Background(), []*Comment{{UID: uuid.New(), UserUID: uuid.New(), Target: target, Body: "body"}})
+	ds.getAll(context.Background(), target, uuid.Nil, 10, 0)
+	if cdb.getAllCalls != 4 {
+		t.Errorf("import did not invalidate cache")
+	}
+}
+
+func TestCachedGetOne(t *testing.T) {
+	cdb := &countingdb{mockdb: &mockdb{}}
+	ds := newCachedDatastore(cdb, CacheConfig{Size: 10, TTL: time.Minute})
+
+	ds.getOne(context.Background(), uuid.Nil)
+	ds.getOne(context.Background(), uuid.Nil)
+	if cdb.getOneCalls != 1 {
+		t.Errorf("unexpected number of datastore calls: got %v want %v", cdb.getOneCalls, 1)
+	}
+
+	body := "new body"
+	if _, err := ds.update(context.Background(), uuid.Nil, commentChanges{body: &body}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	ds.getOne(context.Background(), uuid.Nil)
+	if cdb.getOneCalls != 2 {
+		t.Errorf("update did not invalidate cache")
+	}
+
+	if _, err := ds.getOne(context.Background(), dummyUID); err != errDummy {
+		t.Errorf("unexpected error: got %v want %v", err, errDummy)
+	}
+
+	ds.getOne(context.Background(), dummyUID)
+	if cdb.getOneCalls != 4 {
+		t.Errorf("error was cached")
+	}
+}
+
+func TestCachedPinnedToPrimary(t *testing.T) {
+	cdb := &countingdb{mockdb: &mockdb{}}
+	ds := newCachedDatastore(cdb, CacheConfig{Size: 10, TTL: time.Minute})
+	target := postTarget(uuid.New())
+	primary := context.WithValue(context.Background(), primaryKey{}, true)
+
+	ds.getAll(context.Background(), target, uuid.Nil, 10, 0)
+	ds.getOne(context.Background(), uuid.Nil)
+	ds.getAll(primary, target, uuid.Nil, 10, 0)
+	ds.getOne(primary, uuid.Nil)
+	if cdb.getAllCalls != 2 || cdb.getOneCalls != 2 {
+		t.Errorf("caller pinned to primary served from cache")
+	}
+
+	ds.getAll(context.Background(), target, uuid.Nil, 10, 0)
+	ds.getOne(context.Background(), uuid.Nil)
+	if cdb.getAllCalls != 2 || cdb.getOneCalls != 2 {
+		t.Errorf("results read from primary are not cached")
+	}
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	c := newLRUCache(2, time.Minute)
+	target := Target{"photo", "42"}
+	c.add("first", target, 1)
+	c.add("second", target, 2)
+	c.get("first")
+	c.add("third", target, 3)
+
+	if _, ok := c.get("second"); ok {
+		t.Errorf("least recently used entry was not evicted")
+	}
+
+	if _, ok := c.get("first"); !ok {
+		t.Errorf("recently used entry was evicted")
+	}
+
+	c.invalidateTarget(target)
+	if _, ok := c.get("third"); ok {
+		t.Errorf("entry of invalidated target is still cached")
+	}
+}
+
+func TestLRUCacheExpiration(t *testing.T) {
+	c := newLRUCache(2, time.Millisecond)
+	c.add("key", postTarget(uuid.New()), 1)
+	time.Sleep(2 * time.Millisecond)
+
+	if _, ok := c.get("key"); ok {
+		t.Errorf("expired entry was returned")
+	}
+}
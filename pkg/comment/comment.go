@@ -1,26 +1,88 @@
 package comment
 
 import (
+	"fmt"
+	"regexp"
+	"time"
+
 	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
-var (
-	statusInvalidUUID  = status.Error(codes.InvalidArgument, "invalid UUID")
-	statusNotFound     = status.Error(codes.NotFound, "comment not found")
-	statusInvalidToken = status.Errorf(codes.Unauthenticated, "invalid token")
+var errBodyTooLong = invalidArgument("body", "comment body is too long")
+
+var deleteModes = map[pb.DeleteMode]deleteMode{
+	pb.DeleteMode_REJECT_IF_HAS_REPLIES: deleteRejectIfReplies,
+	pb.DeleteMode_CASCADE:               deleteCascade,
+	pb.DeleteMode_TOMBSTONE:             deleteTombstone,
+}
+
+// mutableFields copy fields which UpdateComment can change from request to changes, keys are SingleComment field paths
+var mutableFields = map[string]func(*pb.UpdateCommentRequest, *commentChanges){
+	"body": func(req *pb.UpdateCommentRequest, changes *commentChanges) { changes.body = &req.Body },
+}
+
+const (
+	defaultBatchSize int32 = 1000
+	maxBatchSize     int32 = 10000
 )
 
-func internalError(err error) error {
-	return status.Error(codes.Internal, err.Error())
+// resourceTypePattern limits resource types to short lowercase identifiers
+var resourceTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+const maxResourceIDLength = 256
+
+// targetOf returns target of request, non-empty postUid is an alias of resource of type post.
+// IDs of posts are normalized, so both ways of addressing a post refer to the same target.
+func targetOf(postUID, resourceType, resourceID string) (Target, error) {
+	idField := "resourceId"
+	if postUID != "" {
+		if (resourceType != "" && resourceType != postType) || (resourceID != "" && resourceID != postUID) {
+			return Target{}, invalidArgument("postUid", "postUid conflicts with resourceType and resourceId")
+		}
+
+		resourceType, resourceID, idField = postType, postUID, "postUid"
+	}
+
+	if !resourceTypePattern.MatchString(resourceType) {
+		return Target{}, invalidArgument("resourceType", "invalid resource type")
+	}
+
+	if resourceType == postType {
+		uid, err := uuid.Parse(resourceID)
+		if err != nil {
+			return Target{}, invalidUUID(idField)
+		}
+
+		resourceID = uid.String()
+	}
+
+	if resourceID == "" || len(resourceID) > maxResourceIDLength {
+		return Target{}, invalidArgument(idField, "invalid resource ID")
+	}
+
+	return Target{resourceType, resourceID}, nil
 }
 
-// SingleComment converts Comment to SingleComment
+// userUIDOf parses UID of a user, nil UUID of guests and UID of erased users identify no user
+func userUIDOf(userUid string) (uuid.UUID, error) {
+	userUID, err := uuid.Parse(userUid)
+	if err != nil || userUID == uuid.Nil || userUID == erasedUserUID {
+		return uuid.Nil, invalidUUID("userUid")
+	}
+
+	return userUID, nil
+}
+
+// SingleComment converts Comment to SingleComment, body of removed comment is replaced with a placeholder
 func (c *Comment) SingleComment() (*pb.SingleComment, error) {
+	return c.singleComment(false)
+}
+
+// singleComment converts Comment to SingleComment keeping body of removed comment if showRemoved is set
+func (c *Comment) singleComment(showRemoved bool) (*pb.SingleComment, error) {
 	createdAtProto, err := ptypes.TimestampProto(c.CreatedAt)
 	if err != nil {
 		return nil, internalError(err)
@@ -34,28 +96,120 @@ func (c *Comment) SingleComment() (*pb.SingleComment, error) {
 	res := new(pb.SingleComment)
 	res.Uid = c.UID.String()
 	res.UserUid = c.UserUID.String()
-	res.PostUid = c.PostUID.String()
+	res.ResourceType = c.Target.Type
+	res.ResourceId = c.Target.ID
+	if c.Target.Type == postType {
+		res.PostUid = c.Target.ID
+	}
 	res.Body = c.Body
 	res.ParentUid = c.ParentUID.String()
 	res.CreatedAt = createdAtProto
 	res.ModifiedAt = modifiedAtProto
 	res.IsDeleted = c.IsDeleted
+	res.GuestName = c.GuestName
+	res.IsPending = c.IsPending
+	if c.IsDeleted {
+		res.RemovedBy = removersByName[c.Removal.By]
+		res.RemovalReason = c.Removal.Reason
+		res.RemovalNotes = c.Removal.Notes
+		if !c.Removal.At.IsZero() {
+			res.RemovedAt, err = ptypes.TimestampProto(c.Removal.At)
+			if err != nil {
+				return nil, internalError(err)
+			}
+		}
+		if !showRemoved {
+			res.Body = removedPlaceholder
+		}
+	}
+
+	return res, nil
+}
+
+// CommentRevision converts Revision to CommentRevision
+func (r *Revision) CommentRevision() (*pb.CommentRevision, error) {
+	createdAtProto, err := ptypes.TimestampProto(r.CreatedAt)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	res := new(pb.CommentRevision)
+	res.Uid = r.UID.String()
+	res.CommentUid = r.CommentUID.String()
+	res.Body = r.Body
+	res.CreatedAt = createdAtProto
+
+	return res, nil
+}
+
+// Draft converts Draft to pb.Draft, ttl is the draft TTL of server
+func (d *Draft) Draft(ttl time.Duration) (*pb.Draft, error) {
+	createdAtProto, err := ptypes.TimestampProto(d.CreatedAt)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	modifiedAtProto, err := ptypes.TimestampProto(d.ModifiedAt)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	res := new(pb.Draft)
+	res.UserUid = d.UserUID.String()
+	res.ResourceType = d.Target.Type
+	res.ResourceId = d.Target.ID
+	if d.Target.Type == postType {
+		res.PostUid = d.Target.ID
+	}
+	res.ParentUid = d.ParentUID.String()
+	res.Body = d.Body
+	res.CreatedAt = createdAtProto
+	res.ModifiedAt = modifiedAtProto
+	if ttl > 0 {
+		res.ExpiresAt, err = ptypes.TimestampProto(d.ModifiedAt.Add(ttl))
+		if err != nil {
+			return nil, internalError(err)
+		}
+	}
 
 	return res, nil
 }
 
-// ListComments returns all comments of post
+// draftKeyOf parses user, target and parent identifying draft
+func draftKeyOf(userUid, postUid, resourceType, resourceID, parentUid string) (uuid.UUID, Target, uuid.UUID, error) {
+	userUID, err := userUIDOf(userUid)
+	if err != nil {
+		return uuid.Nil, Target{}, uuid.Nil, err
+	}
+
+	target, err := targetOf(postUid, resourceType, resourceID)
+	if err != nil {
+		return uuid.Nil, Target{}, uuid.Nil, err
+	}
+
+	parentUID := uuid.Nil
+	if parentUid != "" {
+		parentUID, err = uuid.Parse(parentUid)
+		if err != nil {
+			return uuid.Nil, Target{}, uuid.Nil, invalidUUID("parentUid")
+		}
+	}
+
+	return userUID, target, parentUID, nil
+}
+
+// ListComments returns comments of post or other resource
 func (s *Server) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
-	var pageSize int32
-	if req.PageSize == 0 {
-		pageSize = 10
-	} else {
-		pageSize = req.PageSize
+	pageSize := s.conf.limits(TenantFromContext(ctx)).pageSize(req.PageSize)
+
+	target, err := targetOf(req.PostUid, req.ResourceType, req.ResourceId)
+	if err != nil {
+		return nil, err
 	}
 
-	postUID, err := uuid.Parse(req.PostUid)
+	showRemoved, err := s.showRemoved(ctx, req.IncludeRemovedBody)
 	if err != nil {
-		return nil, statusInvalidUUID
+		return nil, err
 	}
 
 	var parentUID uuid.UUID
@@ -64,145 +218,560 @@ func (s *Server) ListComments(ctx context.Context, req *pb.ListCommentsRequest)
 	} else {
 		parentUID, err = uuid.Parse(req.CommentUid)
 		if err != nil {
-			return nil, statusInvalidUUID
+			return nil, invalidUUID("commentUid")
 		}
 	}
 
-	comments, err := s.db.getAll(postUID, parentUID, pageSize, req.PageNumber)
+	comments, err := s.db.getAll(ctx, target, parentUID, pageSize, req.PageNumber)
 	if err != nil {
-		return nil, internalError(err)
+		return nil, toStatus(err)
 	}
 
 	res := new(pb.ListCommentsResponse)
 	for _, comment := range comments {
-		singleComment, err := comment.SingleComment()
+		singleComment, err := comment.singleComment(showRemoved)
 		if err != nil {
 			return nil, err
 		}
 		res.Comments = append(res.Comments, singleComment)
 	}
 
+	if showRemoved {
+		auditRemovedBodies(ctx, "ListComments", comments...)
+	}
+
 	res.PageSize = pageSize
 	res.PageNumber = req.PageNumber
 
 	return res, nil
 }
 
-// GetPost returns single post by ID
+// GetComment returns single comment by UID, pending comments are only listed by ListPendingComments
 func (s *Server) GetComment(ctx context.Context, req *pb.GetCommentRequest) (*pb.SingleComment, error) {
 	uid, err := uuid.Parse(req.Uid)
 	if err != nil {
-		return nil, statusInvalidUUID
+		return nil, invalidUUID("uid")
 	}
 
-	comment, err := s.db.getOne(uid)
-	switch err {
-	case nil:
-		return comment.SingleComment()
-	case errNotFound:
-		return nil, statusNotFound
-	default:
-		return nil, internalError(err)
+	showRemoved, err := s.showRemoved(ctx, req.IncludeRemovedBody)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.db.getOne(ctx, uid)
+	if err != nil {
+		return nil, toStatus(err)
 	}
+
+	if comment.IsPending {
+		return nil, errNotFound
+	}
+
+	if showRemoved {
+		auditRemovedBodies(ctx, "GetComment", comment)
+	}
+
+	return comment.singleComment(showRemoved)
 }
 
-// CreateComment creates a new comment
+// CreateComment creates a new comment of user or guest
 func (s *Server) CreateComment(ctx context.Context, req *pb.CreateCommentRequest) (*pb.SingleComment, error) {
-	postUID, err := uuid.Parse(req.PostUid)
+	target, err := targetOf(req.PostUid, req.ResourceType, req.ResourceId)
 	if err != nil {
-		return nil, statusInvalidUUID
+		return nil, err
 	}
 
 	parentUID := uuid.Nil
 	if req.ParentUid != "" {
 		parentUID, err = uuid.Parse(req.ParentUid)
 		if err != nil {
-			return nil, statusInvalidUUID
+			return nil, invalidUUID("parentUid")
 		}
 	}
 
-	userUID, err := uuid.Parse(req.UserUid)
-	if err != nil {
-		return nil, statusInvalidUUID
+	tenant := TenantFromContext(ctx)
+	userUID := uuid.Nil
+	if req.Guest == nil {
+		userUID, err = userUIDOf(req.UserUid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.conf.limits(tenant).bodyTooLong(req.Body) {
+		return nil, errBodyTooLong
+	}
+
+	var guest *Guest
+	if req.Guest != nil {
+		guest, err = s.guestOf(tenant, req)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	comment, err := s.db.create(postUID, req.Body, parentUID, userUID)
+	comment, err := s.db.create(ctx, target, req.Body, parentUID, userUID, guest)
 	if err != nil {
-		return nil, internalError(err)
+		return nil, toStatus(err)
 	}
 
 	return comment.SingleComment()
 }
 
-// UpdateComment updates comment by ID
-func (s *Server) UpdateComment(ctx context.Context, req *pb.UpdateCommentRequest) (*pb.UpdateCommentResponse, error) {
+// UpdateComment changes fields of comment listed in update mask and returns updated comment
+func (s *Server) UpdateComment(ctx context.Context, req *pb.UpdateCommentRequest) (*pb.SingleComment, error) {
 	uid, err := uuid.Parse(req.Uid)
 	if err != nil {
-		return nil, statusInvalidUUID
+		return nil, invalidUUID("uid")
 	}
 
-	err = s.db.update(uid, req.Body)
-	switch err {
-	case nil:
-		return new(pb.UpdateCommentResponse), nil
-	case errNotFound:
-		return nil, statusNotFound
-	default:
-		return nil, internalError(err)
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		for path := range mutableFields {
+			paths = append(paths, path)
+		}
 	}
+
+	var changes commentChanges
+	for _, path := range paths {
+		set, ok := mutableFields[path]
+		if !ok {
+			return nil, invalidArgument("updateMask", fmt.Sprintf("field %q can't be updated", path))
+		}
+
+		set(req, &changes)
+	}
+
+	if changes.body != nil && s.conf.limits(TenantFromContext(ctx)).bodyTooLong(*changes.body) {
+		return nil, errBodyTooLong
+	}
+
+	comment, err := s.db.update(ctx, uid, changes)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return comment.SingleComment()
 }
 
+// RemoveContent hides body of comment, recording who removed it and why
 func (s *Server) RemoveContent(ctx context.Context, req *pb.RemoveContentRequest) (*pb.RemoveContentResponse, error) {
 	uid, err := uuid.Parse(req.Uid)
 	if err != nil {
-		return nil, statusInvalidUUID
+		return nil, invalidUUID("uid")
 	}
 
-	err = s.db.removeContent(uid)
-	switch err {
-	case nil:
-		return new(pb.RemoveContentResponse), nil
-	case errNotFound:
-		return nil, statusNotFound
-	default:
-		return nil, internalError(err)
+	removal, err := s.conf.Moderation.removal(req.RemovedBy, req.Reason, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.removeContent(ctx, uid, removal)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return new(pb.RemoveContentResponse), nil
+}
+
+// RestoreContent makes removed comment visible again
+func (s *Server) RestoreContent(ctx context.Context, req *pb.RestoreContentRequest) (*pb.RestoreContentResponse, error) {
+	uid, err := uuid.Parse(req.Uid)
+	if err != nil {
+		return nil, invalidUUID("uid")
+	}
+
+	err = s.db.restoreContent(ctx, uid)
+	if err != nil {
+		return nil, toStatus(err)
 	}
+
+	return new(pb.RestoreContentResponse), nil
 }
 
-// DeleteComment deletes post by ID
+// DeleteComment deletes comment by ID, handling its replies according to requested mode
 func (s *Server) DeleteComment(ctx context.Context, req *pb.DeleteCommentRequest) (*pb.DeleteCommentResponse, error) {
 	uid, err := uuid.Parse(req.Uid)
+	if err != nil {
+		return nil, invalidUUID("uid")
+	}
+
+	mode, ok := deleteModes[req.Mode]
+	if !ok {
+		return nil, errBadMode
+	}
+
+	removal, err := s.conf.Moderation.removal(req.RemovedBy, req.Reason, req.Notes)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.db.delete(uid)
-	switch err {
-	case nil:
-		return new(pb.DeleteCommentResponse), nil
-	case errNotFound:
-		return nil, statusNotFound
-	default:
-		return nil, internalError(err)
+	nRows, err := s.db.delete(ctx, uid, mode, removal)
+	if err != nil {
+		return nil, toStatus(err)
 	}
+
+	res := new(pb.DeleteCommentResponse)
+	res.DeletedCount = nRows
+	res.Tombstoned = nRows == 0
+	return res, nil
 }
 
 // GetOwner returns comment owner
 func (s *Server) GetOwner(ctx context.Context, req *pb.GetOwnerRequest) (*pb.GetOwnerResponse, error) {
 	uid, err := uuid.Parse(req.Uid)
 	if err != nil {
-		return nil, statusInvalidUUID
+		return nil, invalidUUID("uid")
+	}
+
+	result, err := s.db.getOwner(ctx, uid)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := new(pb.GetOwnerResponse)
+	res.OwnerUid = result
+	return res, nil
+}
+
+// ExportUserData streams every comment written by user along with its revisions
+func (s *Server) ExportUserData(req *pb.ExportUserDataRequest, stream pb.Comment_ExportUserDataServer) error {
+	userUID, err := userUIDOf(req.UserUid)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	comments, err := s.db.getAllByUser(ctx, userUID)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	revisions, err := s.db.getRevisionsByUser(ctx, userUID)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	revisionsByComment := make(map[uuid.UUID][]*pb.CommentRevision)
+	for _, revision := range revisions {
+		commentRevision, err := revision.CommentRevision()
+		if err != nil {
+			return err
+		}
+		revisionsByComment[revision.CommentUID] = append(revisionsByComment[revision.CommentUID], commentRevision)
+	}
+
+	for _, comment := range comments {
+		// removed comments still belong to user whoever removed them, so their bodies are exported with their removal
+		singleComment, err := comment.singleComment(true)
+		if err != nil {
+			return err
+		}
+
+		record := new(pb.UserDataRecord)
+		record.Comment = singleComment
+		record.Revisions = revisionsByComment[comment.UID]
+		if err := stream.Send(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EraseUser redacts all comments of user and replaces their author with a tombstone
+func (s *Server) EraseUser(ctx context.Context, req *pb.EraseUserRequest) (*pb.EraseUserResponse, error) {
+	userUID, err := userUIDOf(req.UserUid)
+	if err != nil {
+		return nil, err
 	}
 
-	result, err := s.db.getOwner(uid)
-	switch err {
-	case nil:
-		res := new(pb.GetOwnerResponse)
-		res.OwnerUid = result
-		return res, nil
-	case errNotFound:
-		return nil, statusNotFound
+	nErased, err := s.db.eraseUser(ctx, userUID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := new(pb.EraseUserResponse)
+	res.ErasedCount = nErased
+	return res, nil
+}
+
+func batchSize(requested int32) int32 {
+	switch {
+	case requested <= 0:
+		return defaultBatchSize
+	case requested > maxBatchSize:
+		return maxBatchSize
 	default:
+		return requested
+	}
+}
+
+// DeleteCommentsForPost deletes all comments of post or other resource in batches
+func (s *Server) DeleteCommentsForPost(ctx context.Context, req *pb.DeleteCommentsForPostRequest) (*pb.DeleteCommentsForPostResponse, error) {
+	target, err := targetOf(req.PostUid, req.ResourceType, req.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	nRows, err := s.db.deleteForTarget(ctx, target, batchSize(req.BatchSize))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := new(pb.DeleteCommentsForPostResponse)
+	res.AffectedCount = nRows
+	return res, nil
+}
+
+// RemoveContentForPost removes content of all comments of post or other resource in batches
+func (s *Server) RemoveContentForPost(ctx context.Context, req *pb.RemoveContentForPostRequest) (*pb.RemoveContentForPostResponse, error) {
+	target, err := targetOf(req.PostUid, req.ResourceType, req.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	nRows, err := s.db.removeContentForTarget(ctx, target, batchSize(req.BatchSize))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := new(pb.RemoveContentForPostResponse)
+	res.AffectedCount = nRows
+	return res, nil
+}
+
+// GetChallenge issues a challenge guests solve to write a comment
+func (s *Server) GetChallenge(ctx context.Context, req *pb.GetChallengeRequest) (*pb.Challenge, error) {
+	tenant := TenantFromContext(ctx)
+	if !s.conf.guestsEnabled(tenant) {
+		return nil, errGuestsDisabled
+	}
+
+	challenge, token := s.conf.Guests.issue(tenant, time.Now())
+	expiresAtProto, err := ptypes.TimestampProto(challenge.expires)
+	if err != nil {
 		return nil, internalError(err)
 	}
+
+	res := new(pb.Challenge)
+	res.Token = token
+	res.Difficulty = int32(challenge.difficulty)
+	res.ExpiresAt = expiresAtProto
+	return res, nil
+}
+
+// ListPendingComments returns comments waiting for approval, oldest first
+func (s *Server) ListPendingComments(ctx context.Context, req *pb.ListPendingCommentsRequest) (*pb.ListCommentsResponse, error) {
+	pageSize := s.conf.limits(TenantFromContext(ctx)).pageSize(req.PageSize)
+	showRemoved, err := s.showRemoved(ctx, req.IncludeRemovedBody)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.db.getPending(ctx, pageSize, req.PageNumber)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := new(pb.ListCommentsResponse)
+	for _, comment := range comments {
+		singleComment, err := comment.singleComment(showRemoved)
+		if err != nil {
+			return nil, err
+		}
+		res.Comments = append(res.Comments, singleComment)
+	}
+
+	if showRemoved {
+		auditRemovedBodies(ctx, "ListPendingComments", comments...)
+	}
+
+	res.PageSize = pageSize
+	res.PageNumber = req.PageNumber
+
+	return res, nil
+}
+
+// ListRemovedComments returns removed comments along with their removal, most recently removed first
+func (s *Server) ListRemovedComments(ctx context.Context, req *pb.ListRemovedCommentsRequest) (*pb.ListCommentsResponse, error) {
+	pageSize := s.conf.limits(TenantFromContext(ctx)).pageSize(req.PageSize)
+	showRemoved, err := s.showRemoved(ctx, req.IncludeRemovedBody)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.db.getRemoved(ctx, req.Reason, pageSize, req.PageNumber)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := new(pb.ListCommentsResponse)
+	for _, comment := range comments {
+		singleComment, err := comment.singleComment(showRemoved)
+		if err != nil {
+			return nil, err
+		}
+		res.Comments = append(res.Comments, singleComment)
+	}
+
+	if showRemoved {
+		auditRemovedBodies(ctx, "ListRemovedComments", comments...)
+	}
+
+	res.PageSize = pageSize
+	res.PageNumber = req.PageNumber
+
+	return res, nil
+}
+
+// ListRemovalReasons returns catalogue of reasons comments may be removed for
+func (s *Server) ListRemovalReasons(ctx context.Context, req *pb.ListRemovalReasonsRequest) (*pb.ListRemovalReasonsResponse, error) {
+	res := new(pb.ListRemovalReasonsResponse)
+	res.Reasons = s.conf.Moderation.removalReasons()
+	return res, nil
+}
+
+// ApproveComment makes pending comment visible
+func (s *Server) ApproveComment(ctx context.Context, req *pb.ApproveCommentRequest) (*pb.SingleComment, error) {
+	uid, err := uuid.Parse(req.Uid)
+	if err != nil {
+		return nil, invalidUUID("uid")
+	}
+
+	comment, err := s.db.approve(ctx, uid)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return comment.SingleComment()
+}
+
+// ClaimGuestComments makes user author of comments guest left with the same email
+func (s *Server) ClaimGuestComments(ctx context.Context, req *pb.ClaimGuestCommentsRequest) (*pb.ClaimGuestCommentsResponse, error) {
+	userUID, err := userUIDOf(req.UserUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validGuestEmail(req.Email) {
+		return nil, invalidArgument("email", "invalid email")
+	}
+
+	nClaimed, err := s.db.claimGuest(ctx, s.conf.Guests.emailHash(req.Email), userUID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := new(pb.ClaimGuestCommentsResponse)
+	res.ClaimedCount = nClaimed
+	return res, nil
+}
+
+// SaveDraft creates or replaces draft of user
+func (s *Server) SaveDraft(ctx context.Context, req *pb.SaveDraftRequest) (*pb.Draft, error) {
+	userUID, target, parentUID, err := draftKeyOf(req.UserUid, req.PostUid, req.ResourceType, req.ResourceId, req.ParentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.conf.limits(TenantFromContext(ctx)).bodyTooLong(req.Body) {
+		return nil, errBodyTooLong
+	}
+
+	draft, err := s.db.saveDraft(ctx, userUID, target, parentUID, req.Body, s.conf.draftsSince(time.Now()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return draft.Draft(s.conf.DraftTTL)
+}
+
+// GetDraft returns draft of user unless it has expired
+func (s *Server) GetDraft(ctx context.Context, req *pb.GetDraftRequest) (*pb.Draft, error) {
+	userUID, target, parentUID, err := draftKeyOf(req.UserUid, req.PostUid, req.ResourceType, req.ResourceId, req.ParentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := s.db.getDraft(ctx, userUID, target, parentUID, s.conf.draftsSince(time.Now()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return draft.Draft(s.conf.DraftTTL)
+}
+
+// ListDrafts returns drafts of user which haven't expired, most recently saved first
+func (s *Server) ListDrafts(ctx context.Context, req *pb.ListDraftsRequest) (*pb.ListDraftsResponse, error) {
+	userUID, err := userUIDOf(req.UserUid)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := s.conf.limits(TenantFromContext(ctx)).pageSize(req.PageSize)
+	drafts, err := s.db.getDrafts(ctx, userUID, s.conf.draftsSince(time.Now()), pageSize, req.PageNumber)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := new(pb.ListDraftsResponse)
+	for _, draft := range drafts {
+		pbDraft, err := draft.Draft(s.conf.DraftTTL)
+		if err != nil {
+			return nil, err
+		}
+		res.Drafts = append(res.Drafts, pbDraft)
+	}
+
+	res.PageSize = pageSize
+	res.PageNumber = req.PageNumber
+
+	return res, nil
+}
+
+// DeleteDraft deletes draft of user
+func (s *Server) DeleteDraft(ctx context.Context, req *pb.DeleteDraftRequest) (*pb.DeleteDraftResponse, error) {
+	userUID, target, parentUID, err := draftKeyOf(req.UserUid, req.PostUid, req.ResourceType, req.ResourceId, req.ParentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.deleteDraft(ctx, userUID, target, parentUID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return new(pb.DeleteDraftResponse), nil
+}
+
+// PublishDraft creates comment from draft the same way CreateComment does and deletes the draft
+func (s *Server) PublishDraft(ctx context.Context, req *pb.PublishDraftRequest) (*pb.SingleComment, error) {
+	userUID, target, parentUID, err := draftKeyOf(req.UserUid, req.PostUid, req.ResourceType, req.ResourceId, req.ParentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := s.db.getDraft(ctx, userUID, target, parentUID, s.conf.draftsSince(time.Now()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	create := &pb.CreateCommentRequest{
+		UserUid:      draft.UserUID.String(),
+		ResourceType: draft.Target.Type,
+		ResourceId:   draft.Target.ID,
+		ParentUid:    draft.ParentUID.String(),
+		Body:         draft.Body,
+	}
+	comment, err := s.CreateComment(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	// comment is already created, so failing here would make client publish it twice,
+	// a draft left behind is harmless and expires eventually
+	s.db.deleteDraft(ctx, userUID, target, parentUID)
+
+	return comment, nil
 }
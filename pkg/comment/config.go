@@ -0,0 +1,284 @@
+package comment
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultPageSize int32 = 10
+
+var statusDisabled = status.Error(codes.Unimplemented, "method is disabled")
+
+// Config describes settings of comment server
+type Config struct {
+	Port int
+	DB   DBConfig
+	TLS  TLSConfig
+	Auth AuthConfig
+
+	Tenants    TenantConfig
+	Guests     GuestConfig
+	Moderation ModerationConfig
+
+	Gateway GatewayConfig
+	Cache   CacheConfig
+
+	Retention RetentionConfig
+
+	Limits   Limits
+	Features Features
+
+	// ConnectionTimeout bounds connection handshake, zero means grpc default
+	ConnectionTimeout time.Duration
+	// RequestTimeout bounds handling of a single RPC, zero means no timeout
+	RequestTimeout time.Duration
+
+	// DraftTTL is how long drafts are kept after they were last saved, zero means drafts never expire
+	DraftTTL time.Duration
+}
+
+// DBConfig describes database connection pools, settings apply to primary and every replica
+type DBConfig struct {
+	ConnString         string
+	ReplicaConnStrings []string
+	MaxOpenConns       int
+	MaxIdleConns       int
+	ConnMaxLifetime    time.Duration
+	// ReadYourWritesWindow pins caller to primary for this long after a write, zero disables pinning
+	ReadYourWritesWindow time.Duration
+}
+
+// Limits restricts requests, zero value means no limit
+type Limits struct {
+	MaxBodyLength   int
+	DefaultPageSize int32
+	MaxPageSize     int32
+}
+
+// Features toggles optional RPCs
+type Features struct {
+	UserDataExport     bool
+	UserErasure        bool
+	BulkPostOperations bool
+	// GuestComments allows comments without an account, tenants may override it
+	GuestComments bool
+}
+
+// Validate checks that config is consistent
+func (c Config) Validate() error {
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid port %d", c.Port)
+	}
+
+	if c.DB.ConnString == "" {
+		return errors.New("database connection string is required")
+	}
+
+	for _, connString := range c.DB.ReplicaConnStrings {
+		if connString == "" {
+			return errors.New("replica connection string must not be empty")
+		}
+	}
+
+	if c.DB.MaxOpenConns < 0 || c.DB.MaxIdleConns < 0 || c.DB.ConnMaxLifetime < 0 || c.DB.ReadYourWritesWindow < 0 {
+		return errors.New("database pool settings must not be negative")
+	}
+
+	if !c.TLS.Insecure && (c.TLS.CertFile == "" || c.TLS.KeyFile == "") {
+		return errors.New("TLS certificate and key are required unless insecure mode is enabled")
+	}
+
+	if len(c.Auth.Policy) != 0 && (c.TLS.Insecure || c.TLS.ClientCAFile == "") {
+		return errors.New("authorization policy requires client CA to be configured")
+	}
+
+	if len(c.Moderation.Identities) != 0 && (c.TLS.Insecure || c.TLS.ClientCAFile == "") {
+		return errors.New("moderator identities require client CA to be configured")
+	}
+
+	if c.Gateway.Port < 0 || c.Gateway.Port > 65535 {
+		return fmt.Errorf("invalid gateway port %d", c.Gateway.Port)
+	}
+
+	if c.Gateway.Port == c.Port {
+		return errors.New("gateway port must differ from gRPC port")
+	}
+
+	if (c.Gateway.CertFile == "") != (c.Gateway.KeyFile == "") {
+		return errors.New("gateway certificate and key must be set together")
+	}
+
+	if c.Gateway.Port != 0 && !c.TLS.Insecure && c.TLS.ClientCAFile != "" && c.Gateway.CertFile == "" {
+		return errors.New("gateway certificate is required when client CA is configured")
+	}
+
+	if c.Gateway.Port != 0 && len(c.Auth.Policy) != 0 && c.Auth.Gateway == "" {
+		return errors.New("gateway identity is required when authorization policy is set")
+	}
+
+	if err := c.Cache.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Limits.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Tenants.Validate(); err != nil {
+		return err
+	}
+
+	for _, identity := range []string{anonymousIdentity, c.Auth.Gateway} {
+		if identity != "" && c.Tenants.trusted(identity) {
+			return fmt.Errorf("identity %s can't be trusted to choose tenant", identity)
+		}
+	}
+
+	if err := c.Moderation.Validate(); err != nil {
+		return err
+	}
+
+	for tenant := range c.Tenants.Settings {
+		if err := c.limits(tenant).validate(); err != nil {
+			return fmt.Errorf("tenant %s: %v", tenant, err)
+		}
+	}
+
+	guestsEnabled := c.Features.GuestComments
+	for tenant := range c.Tenants.Settings {
+		guestsEnabled = guestsEnabled || c.guestsEnabled(tenant)
+	}
+
+	if err := c.Guests.Validate(guestsEnabled); err != nil {
+		return err
+	}
+
+	if c.ConnectionTimeout < 0 || c.RequestTimeout < 0 {
+		return errors.New("timeouts must not be negative")
+	}
+
+	if c.DraftTTL < 0 {
+		return errors.New("draft TTL must not be negative")
+	}
+
+	if err := c.Retention.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l Limits) validate() error {
+	if l.MaxBodyLength < 0 || l.DefaultPageSize < 0 || l.MaxPageSize < 0 {
+		return errors.New("limits must not be negative")
+	}
+
+	if l.MaxPageSize != 0 && l.DefaultPageSize > l.MaxPageSize {
+		return errors.New("default page size exceeds max page size")
+	}
+
+	return nil
+}
+
+// override returns limits with non-zero limits of o replacing these ones
+func (l Limits) override(o Limits) Limits {
+	if o.MaxBodyLength != 0 {
+		l.MaxBodyLength = o.MaxBodyLength
+	}
+
+	if o.DefaultPageSize != 0 {
+		l.DefaultPageSize = o.DefaultPageSize
+	}
+
+	if o.MaxPageSize != 0 {
+		l.MaxPageSize = o.MaxPageSize
+	}
+
+	return l
+}
+
+// draftsSince returns time drafts saved before have expired
+func (c Config) draftsSince(now time.Time) time.Time {
+	if c.DraftTTL == 0 {
+		return time.Time{}
+	}
+
+	return now.Add(-c.DraftTTL)
+}
+
+// pageSize returns page size to use for requested one
+func (l Limits) pageSize(requested int32) int32 {
+	pageSize := requested
+	if pageSize <= 0 {
+		pageSize = l.DefaultPageSize
+	}
+
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	if l.MaxPageSize > 0 && pageSize > l.MaxPageSize {
+		pageSize = l.MaxPageSize
+	}
+
+	return pageSize
+}
+
+// bodyTooLong reports whether body exceeds the limit
+func (l Limits) bodyTooLong(body string) bool {
+	return l.MaxBodyLength > 0 && len([]rune(body)) > l.MaxBodyLength
+}
+
+// enabled reports whether method is enabled by feature toggles
+func (f Features) enabled(method string) bool {
+	switch method {
+	case "ExportUserData":
+		return f.UserDataExport
+	case "EraseUser":
+		return f.UserErasure
+	case "DeleteCommentsForPost", "RemoveContentForPost":
+		return f.BulkPostOperations
+	default:
+		return true
+	}
+}
+
+// unaryInterceptor rejects disabled methods and applies request timeout
+func (c Config) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !c.Features.enabled(methodName(info.FullMethod)) {
+			return nil, statusDisabled
+		}
+
+		if c.RequestTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.RequestTimeout)
+			defer cancel()
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// streamInterceptor rejects disabled methods and applies request timeout
+func (c Config) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !c.Features.enabled(methodName(info.FullMethod)) {
+			return statusDisabled
+		}
+
+		if c.RequestTimeout > 0 {
+			ctx, cancel := context.WithTimeout(stream.Context(), c.RequestTimeout)
+			defer cancel()
+			stream = &serverStream{stream, ctx}
+		}
+
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,173 @@
+package comment
+
+import (
+	"database/sql/driver"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/lib/pq"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retryDelay is suggested to clients retrying after conflict or unavailable errors
+const retryDelay = time.Second
+
+// errorKind classifies domain errors, every kind is sent to clients with its own gRPC code
+type errorKind int
+
+const (
+	kindNotFound errorKind = iota
+	kindConflict
+	kindInvalidArgument
+	kindPrecondition
+	kindUnavailable
+)
+
+var kindCodes = map[errorKind]codes.Code{
+	kindNotFound:        codes.NotFound,
+	kindConflict:        codes.Aborted,
+	kindInvalidArgument: codes.InvalidArgument,
+	kindPrecondition:    codes.FailedPrecondition,
+	kindUnavailable:     codes.Unavailable,
+}
+
+// domainError is an error clients can act upon.
+// Message is sent to clients, cause is only written to request log.
+type domainError struct {
+	kind    errorKind
+	message string
+	// subject is the resource type of not found errors, the request field of invalid argument errors
+	// and the violated condition of precondition errors
+	subject string
+	cause   error
+}
+
+func (e *domainError) Error() string {
+	if e.cause != nil {
+		return e.message + ": " + e.cause.Error()
+	}
+
+	return e.message
+}
+
+// GRPCStatus maps domain error to status with error details attached
+func (e *domainError) GRPCStatus() *status.Status {
+	st := status.New(kindCodes[e.kind], e.message)
+
+	var detail proto.Message
+	switch e.kind {
+	case kindNotFound:
+		detail = &errdetails.ResourceInfo{ResourceType: e.subject, Description: e.message}
+	case kindInvalidArgument:
+		detail = &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: e.subject, Description: e.message}},
+		}
+	case kindPrecondition:
+		detail = &errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{Type: e.subject, Description: e.message}},
+		}
+	case kindConflict, kindUnavailable:
+		detail = &errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(retryDelay)}
+	}
+
+	withDetails, err := st.WithDetails(detail)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+func notFound(resource, message string) error {
+	return &domainError{kind: kindNotFound, message: message, subject: resource}
+}
+
+func invalidArgument(field, message string) error {
+	return &domainError{kind: kindInvalidArgument, message: message, subject: field}
+}
+
+func invalidUUID(field string) error {
+	return invalidArgument(field, "invalid UUID")
+}
+
+func preconditionFailed(condition, message string) error {
+	return &domainError{kind: kindPrecondition, message: message, subject: condition}
+}
+
+func conflict(message string, cause error) error {
+	return &domainError{kind: kindConflict, message: message, cause: cause}
+}
+
+func unavailable(message string, cause error) error {
+	return &domainError{kind: kindUnavailable, message: message, cause: cause}
+}
+
+// Postgres error codes translated to domain errors
+const (
+	pqUniqueViolation      = "23505"
+	pqForeignKeyViolation  = "23503"
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+	pqTooManyConnections   = "53300"
+	pqAdminShutdown        = "57P01"
+	pqCrashShutdown        = "57P02"
+	pqCannotConnectNow     = "57P03"
+	pqConnectionException  = "08"
+)
+
+// fromPostgres translates database errors to domain errors, other errors are returned as is
+func fromPostgres(err error) error {
+	if err == driver.ErrBadConn {
+		return unavailable("database unavailable", err)
+	}
+
+	if _, ok := err.(net.Error); ok {
+		return unavailable("database unavailable", err)
+	}
+
+	pqErr, ok := err.(*pq.Error)
+	if !ok {
+		return err
+	}
+
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		return conflict("comment already exists", err)
+	case pqForeignKeyViolation:
+		return &domainError{kind: kindPrecondition, message: "referenced comment does not exist", subject: "REFERENCE", cause: err}
+	case pqSerializationFailure, pqDeadlockDetected:
+		return conflict("concurrent modification, retry the request", err)
+	case pqTooManyConnections, pqAdminShutdown, pqCrashShutdown, pqCannotConnectNow:
+		return unavailable("database unavailable", err)
+	}
+
+	if pqErr.Code.Class() == pqConnectionException {
+		return unavailable("database unavailable", err)
+	}
+
+	return err
+}
+
+// toStatus converts error returned by datastore to error sent to client.
+// Errors unknown to clients are hidden behind internal error.
+func toStatus(err error) error {
+	switch err {
+	case nil:
+		return nil
+	case context.Canceled:
+		return status.Error(codes.Canceled, "request canceled")
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	}
+
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return err
+	}
+
+	return internalError(err)
+}
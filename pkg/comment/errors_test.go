@@ -0,0 +1,89 @@
+package comment
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/lib/pq"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromPostgres(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{&pq.Error{Code: pqUniqueViolation}, codes.Aborted},
+		{&pq.Error{Code: pqForeignKeyViolation}, codes.FailedPrecondition},
+		{&pq.Error{Code: pqSerializationFailure}, codes.Aborted},
+		{&pq.Error{Code: pqDeadlockDetected}, codes.Aborted},
+		{&pq.Error{Code: pqAdminShutdown}, codes.Unavailable},
+		{&pq.Error{Code: "08006"}, codes.Unavailable},
+		{driver.ErrBadConn, codes.Unavailable},
+		{&pq.Error{Code: "42601"}, codes.Internal},
+		{errDummy, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		err := toStatus(fromPostgres(tt.err))
+		if code := status.Code(err); code != tt.code {
+			t.Errorf("%v: unexpected code: got %v want %v", tt.err, code, tt.code)
+		}
+	}
+
+	if err := fromPostgres(sql.ErrNoRows); err != sql.ErrNoRows {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{nil, codes.OK},
+		{errNotFound, codes.NotFound},
+		{errHasReplies, codes.FailedPrecondition},
+		{invalidUUID("uid"), codes.InvalidArgument},
+		{context.Canceled, codes.Canceled},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{status.Error(codes.PermissionDenied, "denied"), codes.PermissionDenied},
+		{errDummy, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		if code := status.Code(toStatus(tt.err)); code != tt.code {
+			t.Errorf("%v: unexpected code: got %v want %v", tt.err, code, tt.code)
+		}
+	}
+}
+
+func TestDomainErrorDetails(t *testing.T) {
+	st := status.Convert(invalidUUID("postUid"))
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("unexpected details %v", details)
+	}
+
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	if !ok || len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "postUid" {
+		t.Errorf("unexpected detail %v", details[0])
+	}
+
+	cause := &pq.Error{Code: pqSerializationFailure, Message: "could not serialize access"}
+	err := fromPostgres(cause)
+	st = status.Convert(err)
+	if st.Message() != "concurrent modification, retry the request" {
+		t.Errorf("cause leaked to client: %q", st.Message())
+	}
+
+	if details := st.Details(); len(details) != 1 {
+		t.Errorf("unexpected details %v", details)
+	} else if _, ok := details[0].(*errdetails.RetryInfo); !ok {
+		t.Errorf("unexpected detail %v", details[0])
+	}
+}
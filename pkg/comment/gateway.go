@@ -0,0 +1,183 @@
+package comment
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// openAPIPath is where OpenAPI document of the gateway is served
+const openAPIPath = "/openapi.json"
+
+// GatewayConfig describes HTTP/JSON gateway to comment server
+type GatewayConfig struct {
+	// Port of HTTP gateway, zero disables gateway
+	Port int
+	// CAFile is a path to PEM encoded CA bundle used to verify gRPC server certificate, system roots are used if empty
+	CAFile string
+	// ServerName is used to verify gRPC server certificate, "localhost" if empty
+	ServerName string
+	// CertFile and KeyFile are presented to gRPC server if it requires client certificates
+	CertFile string
+	KeyFile  string
+}
+
+// gatewayHeaderMatcher forwards request ID and session headers along with the default ones.
+// Caller and tenant headers are never taken from HTTP clients, even as Grpc-Metadata- headers:
+// tenant of a gateway request is resolved from identity of its client like for any other caller.
+func gatewayHeaderMatcher(key string) (string, bool) {
+	for _, header := range []string{requestIDHeader, sessionHeader} {
+		if strings.EqualFold(key, header) {
+			return header, true
+		}
+	}
+
+	key, ok := runtime.DefaultHeaderMatcher(key)
+	if strings.EqualFold(key, callerHeader) || strings.EqualFold(key, tenantHeader) {
+		return "", false
+	}
+
+	return key, ok
+}
+
+// callerMetadata names identity of HTTP client's verified certificate, clients without one stay anonymous
+func (c AuthConfig) callerMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return nil
+	}
+
+	identity, ok := c.identity(r.TLS.VerifiedChains[0][0])
+	if !ok {
+		return nil
+	}
+
+	return metadata.Pairs(callerHeader, identity)
+}
+
+// dialOptions returns options to connect gateway to gRPC server
+func (c Config) dialOptions() ([]grpc.DialOption, error) {
+	if c.TLS.Insecure {
+		return []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+
+	serverName := c.Gateway.ServerName
+	if serverName == "" {
+		serverName = "localhost"
+	}
+
+	config := &tls.Config{ServerName: serverName}
+	if c.Gateway.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.Gateway.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", c.Gateway.CAFile)
+		}
+	}
+
+	if c.Gateway.CertFile != "" {
+		reloader := &certReloader{certFile: c.Gateway.CertFile, keyFile: c.Gateway.KeyFile}
+		if err := reloader.load(); err != nil {
+			return nil, err
+		}
+
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			reloader.maybeReload()
+			cert, _ := reloader.get()
+			return cert, nil
+		}
+	}
+
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}, nil
+}
+
+// gatewayHandler returns HTTP handler translating REST calls to RPCs sent over conn on behalf of HTTP clients
+func gatewayHandler(ctx context.Context, conn *grpc.ClientConn, auth AuthConfig) (http.Handler, error) {
+	gw := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher), runtime.WithMetadata(auth.callerMetadata))
+	if err := pb.RegisterCommentHandler(ctx, gw, conn); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", gw)
+	mux.HandleFunc(openAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, pb.SwaggerJSON)
+	})
+
+	return mux, nil
+}
+
+// StartGateway starts HTTP/JSON gateway, it does nothing if gateway is disabled.
+// Gateway calls the gRPC server started by Start, so requests pass through the same interceptors
+// and are authorized by identity of HTTP client certificate.
+func (s *Server) StartGateway() error {
+	if s.conf.Gateway.Port == 0 {
+		return nil
+	}
+
+	opts, err := s.conf.dialOptions()
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", s.conf.Port), opts...)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	handler, err := gatewayHandler(context.Background(), conn, s.conf.Auth)
+	if err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.conf.Gateway.Port))
+	if err != nil {
+		return err
+	}
+
+	// browsers can't present client certificates, so clients without one are served as anonymous
+	config, err := s.conf.TLS.serverConfig(tls.VerifyClientCertIfGiven)
+	if err != nil {
+		lis.Close()
+		return err
+	}
+
+	if config != nil {
+		lis = tls.NewListener(lis, config)
+	}
+
+	server := &http.Server{Handler: handler}
+	s.mu.Lock()
+	select {
+	case <-s.stop:
+		s.mu.Unlock()
+		lis.Close()
+		return nil
+	default:
+		s.gateway = server
+	}
+	s.mu.Unlock()
+
+	if err := server.Serve(lis); err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package comment
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+func newTestGateway(t *testing.T) (http.Handler, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	server := grpc.NewServer()
+	pb.RegisterCommentServer(server, &Server{db: &mockdb{}})
+	go server.Serve(lis)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	handler, err := gatewayHandler(context.Background(), conn, AuthConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	return handler, func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestGatewayGetComment(t *testing.T) {
+	handler, cleanup := newTestGateway(t)
+	defer cleanup()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/comments/"+nilUIDString, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %v want %v", w.Code, http.StatusOK)
+	}
+
+	var comment pb.SingleComment
+	if err := jsonpb.Unmarshal(w.Body, &comment); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if comment.Body != "first comment body" {
+		t.Errorf("unexpected body %q", comment.Body)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/comments/"+uuid.New().String(), nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("unexpected status: got %v want %v", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestGatewayOpenAPI(t *testing.T) {
+	handler, cleanup := newTestGateway(t)
+	defer cleanup()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", openAPIPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %v want %v", w.Code, http.StatusOK)
+	}
+
+	var doc struct {
+		Paths map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, ok := doc.Paths["/posts/{postUid}/comments"]; !ok {
+		t.Errorf("path /posts/{postUid}/comments is not documented")
+	}
+}
+
+func TestGatewayHeaderMatcher(t *testing.T) {
+	if key, ok := gatewayHeaderMatcher("X-Request-Id"); !ok || key != requestIDHeader {
+		t.Errorf("request ID header is not forwarded: got %v, %v", key, ok)
+	}
+
+	if _, ok := gatewayHeaderMatcher("X-Unknown"); ok {
+		t.Errorf("unexpected header forwarded")
+	}
+
+	for _, header := range []string{"X-Comment-Caller", "Grpc-Metadata-X-Comment-Caller", "X-Comment-Tenant", "Grpc-Metadata-X-Comment-Tenant"} {
+		if _, ok := gatewayHeaderMatcher(header); ok {
+			t.Errorf("%s is forwarded", header)
+		}
+	}
+}
+
+func TestGatewayCallerMetadata(t *testing.T) {
+	c := AuthConfig{Identities: map[string]string{"web.internal": "web"}}
+	r := httptest.NewRequest("GET", "/comments/"+nilUIDString, nil)
+	if md := c.callerMetadata(context.Background(), r); len(md) != 0 {
+		t.Errorf("expected no caller of plaintext request, got %v", md)
+	}
+
+	cert := &x509.Certificate{DNSNames: []string{"web.internal"}}
+	r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	if md := c.callerMetadata(context.Background(), r); len(md.Get(callerHeader)) != 1 || md.Get(callerHeader)[0] != "web" {
+		t.Errorf("expected caller web, got %v", md)
+	}
+
+	cert.DNSNames = []string{"unknown.internal"}
+	if md := c.callerMetadata(context.Background(), r); len(md) != 0 {
+		t.Errorf("expected no caller of unknown certificate, got %v", md)
+	}
+}
@@ -0,0 +1,226 @@
+package comment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"github.com/google/uuid"
+)
+
+const (
+	maxDifficulty       = 32
+	maxGuestNameLength  = 64
+	maxGuestEmailLength = 254
+	minGuestSecretLen   = 16
+)
+
+var (
+	errGuestsDisabled    = preconditionFailed("GUESTS_DISABLED", "guest comments are disabled")
+	errInvalidChallenge  = invalidArgument("guest.challengeToken", "invalid challenge")
+	errChallengeExpired  = invalidArgument("guest.challengeToken", "challenge expired")
+	errChallengeUsed     = invalidArgument("guest.challengeToken", "challenge was already used")
+	errWrongSolution     = invalidArgument("guest.solution", "wrong challenge solution")
+	errInvalidGuestName  = invalidArgument("guest.displayName", "display name is required and must be short")
+	errInvalidGuestEmail = invalidArgument("guest.email", "invalid email")
+	errGuestWithUser     = invalidArgument("userUid", "userUid must be empty for guest comments")
+)
+
+// GuestConfig describes comments written without an account
+type GuestConfig struct {
+	// Secret signs challenges and keys hashes of guest emails.
+	// Changing it invalidates issued challenges and makes existing guest comments unclaimable.
+	Secret string
+	// Difficulty is the number of leading zero bits of proof of work, zero requires only a signed challenge
+	Difficulty int
+	// ChallengeTTL limits how long a challenge can be used
+	ChallengeTTL time.Duration
+	// Publish makes guest comments visible without approval of a moderator
+	Publish bool
+}
+
+// Validate checks that guest config is consistent, enabled tells whether any tenant allows guest comments
+func (c GuestConfig) Validate(enabled bool) error {
+	if c.Difficulty < 0 || c.Difficulty > maxDifficulty {
+		return fmt.Errorf("guest challenge difficulty must be between 0 and %d", maxDifficulty)
+	}
+
+	if c.ChallengeTTL < 0 {
+		return errors.New("guest challenge TTL must not be negative")
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	if len(c.Secret) < minGuestSecretLen {
+		return fmt.Errorf("guest secret of at least %d bytes is required when guest comments are enabled", minGuestSecretLen)
+	}
+
+	if c.ChallengeTTL == 0 {
+		return errors.New("guest challenge TTL is required when guest comments are enabled")
+	}
+
+	return nil
+}
+
+// guestsEnabled reports whether tenant allows guest comments
+func (c Config) guestsEnabled(tenant string) bool {
+	if enabled := c.Tenants.Settings[tenant].GuestComments; enabled != nil {
+		return *enabled
+	}
+
+	return c.Features.GuestComments
+}
+
+// challenge is a server signed puzzle a guest must solve to write a comment
+type challenge struct {
+	uid        uuid.UUID
+	tenant     string
+	difficulty int
+	expires    time.Time
+}
+
+func (c GuestConfig) mac(purpose string, data []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(c.Secret))
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// issue returns a new challenge of tenant along with its token
+func (c GuestConfig) issue(tenant string, now time.Time) (challenge, string) {
+	ch := challenge{uuid.New(), tenant, c.Difficulty, now.Add(c.ChallengeTTL)}
+	payload := []byte(fmt.Sprintf("%s:%d:%d:%s", ch.uid, ch.expires.Unix(), ch.difficulty, ch.tenant))
+	encoding := base64.RawURLEncoding
+	return ch, encoding.EncodeToString(payload) + "." + encoding.EncodeToString(c.mac("challenge", payload))
+}
+
+// verify checks that token was issued to tenant, hasn't expired and solution is correct
+func (c GuestConfig) verify(tenant, token, solution string, now time.Time) (challenge, error) {
+	var ch challenge
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return ch, errInvalidChallenge
+	}
+
+	encoding := base64.RawURLEncoding
+	payload, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return ch, errInvalidChallenge
+	}
+
+	signature, err := encoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, c.mac("challenge", payload)) {
+		return ch, errInvalidChallenge
+	}
+
+	fields := strings.SplitN(string(payload), ":", 4)
+	if len(fields) != 4 {
+		return ch, errInvalidChallenge
+	}
+
+	ch.uid, err = uuid.Parse(fields[0])
+	if err != nil {
+		return ch, errInvalidChallenge
+	}
+
+	expires, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return ch, errInvalidChallenge
+	}
+
+	ch.expires = time.Unix(expires, 0)
+	ch.difficulty, err = strconv.Atoi(fields[2])
+	if err != nil {
+		return ch, errInvalidChallenge
+	}
+
+	ch.tenant = fields[3]
+	if ch.tenant != tenant {
+		return ch, errInvalidChallenge
+	}
+
+	if now.After(ch.expires) {
+		return ch, errChallengeExpired
+	}
+
+	if leadingZeroBits(sha256.Sum256([]byte(token+solution))) < ch.difficulty {
+		return ch, errWrongSolution
+	}
+
+	return ch, nil
+}
+
+func leadingZeroBits(sum [sha256.Size]byte) int {
+	n := 0
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+
+		n += 8
+	}
+
+	return n
+}
+
+// emailHash returns keyed hash of normalized email, so stored hashes can't be reversed by hashing known addresses
+func (c GuestConfig) emailHash(email string) string {
+	return hex.EncodeToString(c.mac("email", []byte(strings.ToLower(strings.TrimSpace(email)))))
+}
+
+// validGuestName reports whether display name is non-empty and short
+func validGuestName(name string) bool {
+	name = strings.TrimSpace(name)
+	return name != "" && utf8.RuneCountInString(name) <= maxGuestNameLength
+}
+
+// validGuestEmail does a basic sanity check, email ownership is verified when comments are claimed
+func validGuestEmail(email string) bool {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	return at > 0 && at < len(email)-1 && len(email) <= maxGuestEmailLength
+}
+
+// guestOf checks guest of request and the challenge it solved, returns author of guest comment
+func (s *Server) guestOf(tenant string, req *pb.CreateCommentRequest) (*Guest, error) {
+	if !s.conf.guestsEnabled(tenant) {
+		return nil, errGuestsDisabled
+	}
+
+	if req.UserUid != "" {
+		return nil, errGuestWithUser
+	}
+
+	if !validGuestName(req.Guest.DisplayName) {
+		return nil, errInvalidGuestName
+	}
+
+	guest := &Guest{Name: strings.TrimSpace(req.Guest.DisplayName), Pending: !s.conf.Guests.Publish}
+	if req.Guest.Email != "" {
+		if !validGuestEmail(req.Guest.Email) {
+			return nil, errInvalidGuestEmail
+		}
+
+		guest.EmailHash = s.conf.Guests.emailHash(req.Guest.Email)
+	}
+
+	challenge, err := s.conf.Guests.verify(tenant, req.Guest.ChallengeToken, req.Guest.Solution, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	guest.ChallengeUID = challenge.uid
+	return guest, nil
+}
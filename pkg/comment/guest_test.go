@@ -0,0 +1,187 @@
+package comment
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"testing"
+	"time"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testGuests = GuestConfig{Secret: "0123456789abcdef", Difficulty: 8, ChallengeTTL: time.Minute}
+
+// solve finds solution of challenge token by brute force
+func solve(token string, difficulty int) string {
+	for i := 0; ; i++ {
+		solution := strconv.Itoa(i)
+		if leadingZeroBits(sha256.Sum256([]byte(token+solution))) >= difficulty {
+			return solution
+		}
+	}
+}
+
+func TestChallenge(t *testing.T) {
+	now := time.Now()
+	issued, token := testGuests.issue("blog", now)
+	solution := solve(token, issued.difficulty)
+
+	ch, err := testGuests.verify("blog", token, solution, now)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if ch.uid != issued.uid || ch.difficulty != testGuests.Difficulty {
+		t.Errorf("unexpected challenge %+v, issued %+v", ch, issued)
+	}
+
+	other := testGuests
+	other.Secret = "fedcba9876543210"
+	tests := []struct {
+		name     string
+		conf     GuestConfig
+		tenant   string
+		token    string
+		solution string
+		now      time.Time
+		err      error
+	}{
+		{"malformed token", testGuests, "blog", "token", solution, now, errInvalidChallenge},
+		{"tampered token", testGuests, "blog", "x" + token, solution, now, errInvalidChallenge},
+		{"other secret", other, "blog", token, solution, now, errInvalidChallenge},
+		{"other tenant", testGuests, "forum", token, solution, now, errInvalidChallenge},
+		{"expired", testGuests, "blog", token, solution, now.Add(2 * time.Minute), errChallengeExpired},
+		{"wrong solution", testGuests, "blog", token, solution + "x", now, errWrongSolution},
+	}
+
+	for _, test := range tests {
+		// a wrong solution may still happen to be correct, skip such rare cases
+		if test.err == errWrongSolution && leadingZeroBits(sha256.Sum256([]byte(test.token+test.solution))) >= issued.difficulty {
+			continue
+		}
+
+		if _, err := test.conf.verify(test.tenant, test.token, test.solution, test.now); err != test.err {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestEmailHash(t *testing.T) {
+	if testGuests.emailHash(" Guest@Example.com") != testGuests.emailHash("guest@example.com") {
+		t.Errorf("expected hash of normalized email")
+	}
+
+	if testGuests.emailHash("guest@example.com") == testGuests.emailHash("other@example.com") {
+		t.Errorf("expected different hashes of different emails")
+	}
+}
+
+func TestGuestConfigValidate(t *testing.T) {
+	if err := (GuestConfig{}).Validate(false); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if err := (GuestConfig{}).Validate(true); err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+
+	if err := testGuests.Validate(true); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestCreateGuestComment(t *testing.T) {
+	disabled := false
+	conf := Config{
+		Guests:   testGuests,
+		Features: Features{GuestComments: true},
+		Tenants:  TenantConfig{Settings: map[string]TenantSettings{"forum": {GuestComments: &disabled}}},
+	}
+	s := &Server{db: &mockdb{}, conf: conf}
+	ctx := withTenant(context.Background(), "blog")
+
+	challenge, err := s.GetChallenge(ctx, &pb.GetChallengeRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	guest := &pb.Guest{DisplayName: "guest", Email: "guest@example.com", ChallengeToken: challenge.Token}
+	guest.Solution = solve(challenge.Token, int(challenge.Difficulty))
+	req := &pb.CreateCommentRequest{PostUid: nilUIDString, Guest: guest}
+	res, err := s.CreateComment(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if res.GuestName != "guest" || !res.IsPending || res.UserUid != nilUIDString {
+		t.Errorf("unexpected comment %v", res)
+	}
+
+	req.UserUid = uuid.New().String()
+	if _, err := s.CreateComment(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	req.UserUid = ""
+	forum := withTenant(context.Background(), "forum")
+	if _, err := s.CreateComment(forum, req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+
+	if _, err := s.GetChallenge(forum, &pb.GetChallengeRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestClaimGuestComments(t *testing.T) {
+	s := &Server{db: &mockdb{}, conf: Config{Guests: testGuests}}
+	req := &pb.ClaimGuestCommentsRequest{UserUid: uuid.New().String(), Email: "guest@example.com"}
+	res, err := s.ClaimGuestComments(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if res.ClaimedCount != 2 {
+		t.Errorf("expected 2 claimed comments, got %d", res.ClaimedCount)
+	}
+
+	req.Email = "guest"
+	if _, err := s.ClaimGuestComments(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	req.UserUid, req.Email = nilUIDString, "guest@example.com"
+	if _, err := s.ClaimGuestComments(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestApproveComment(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	res, err := s.ApproveComment(context.Background(), &pb.ApproveCommentRequest{Uid: nilUIDString})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if res.IsPending {
+		t.Errorf("expected approved comment, got %v", res)
+	}
+
+	_, err = s.ApproveComment(context.Background(), &pb.ApproveCommentRequest{Uid: uuid.New().String()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	pending, err := s.ListPendingComments(context.Background(), &pb.ListPendingCommentsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(pending.Comments) != 1 || !pending.Comments[0].IsPending {
+		t.Errorf("unexpected pending comments %v", pending.Comments)
+	}
+}
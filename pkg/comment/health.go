@@ -0,0 +1,106 @@
+package comment
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	healthServiceName   = "comment.Comment"
+	healthCheckInterval = 5 * time.Second
+	pingTimeout         = 2 * time.Second
+)
+
+// healthServer implements grpc.health.v1.Health.
+// Unlike health.Server from grpc it reports real status for the overall ("") service too.
+type healthServer struct {
+	mu           sync.RWMutex
+	status       healthpb.HealthCheckResponse_ServingStatus
+	shuttingDown bool
+}
+
+func newHealthServer() *healthServer {
+	return &healthServer{status: healthpb.HealthCheckResponse_NOT_SERVING}
+}
+
+// Check returns serving status of the server
+func (h *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.Service != "" && req.Service != healthServiceName {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return &healthpb.HealthCheckResponse{Status: h.status}, nil
+}
+
+// Watch is not supported
+func (h *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	return status.Error(codes.Unimplemented, "watching is not supported")
+}
+
+// setServingStatus updates status unless server is shutting down
+func (h *healthServer) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.shuttingDown {
+		h.status = status
+	}
+}
+
+// shutdown permanently marks server as not serving
+func (h *healthServer) shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.shuttingDown = true
+	h.status = healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// updateHealth pings datastore and sets serving status accordingly
+func (s *Server) updateHealth() healthpb.HealthCheckResponse_ServingStatus {
+	serving := healthpb.HealthCheckResponse_SERVING
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	if err := s.db.ping(ctx); err != nil {
+		log.Printf("datastore ping failed: %v", err)
+		serving = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	s.health.setServingStatus(serving)
+	return serving
+}
+
+// watchHealth periodically updates serving status until stop is closed
+func (s *Server) watchHealth(stop <-chan struct{}) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		s.updateHealth()
+		select {
+		case <-stop:
+			s.health.shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ping checks primary and updates health of replicas, only primary affects the result
+func (db *db) ping(ctx context.Context) error {
+	db.pingReplicas(ctx)
+	return db.PingContext(ctx)
+}
+
+func (db *db) close() error {
+	for _, r := range db.replicas {
+		r.Close()
+	}
+
+	return db.Close()
+}
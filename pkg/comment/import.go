@@ -0,0 +1,137 @@
+package comment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"golang.org/x/net/context"
+)
+
+// reasonRemovedAtSource is the removal reason of comments which were already removed in the system they were imported from
+const reasonRemovedAtSource = "removed_at_source"
+
+// importNamespace is the namespace of UIDs of imported comments
+var importNamespace = uuid.Must(uuid.Parse("5c1d0f64-3f0e-4c1b-9a53-0b8f1f0e6a27"))
+
+// ImportUID returns UID of comment imported from source. The same external comment always gets the same UID,
+// so importing an export again doesn't duplicate comments.
+func ImportUID(tenant, source, externalID string) uuid.UUID {
+	return uuid.NewSHA1(importNamespace, []byte(tenant+"\x00"+source+"\x00"+externalID))
+}
+
+// RemovedAtSource returns removal of comment which was already removed in the system it was imported from
+func RemovedAtSource() Removal {
+	return Removal{By: removedBySystem, Reason: reasonRemovedAtSource}
+}
+
+// ErasedUserUID returns user UID of comments whose author was erased, removed comments of unknown authors are imported with it
+func ErasedUserUID() uuid.UUID {
+	return erasedUserUID
+}
+
+// Importer writes comments migrated from other systems to the datastore.
+// Servers cache comments, so imported comments may appear in responses only after cache TTL.
+type Importer struct {
+	db     datastore
+	limits Limits
+}
+
+// NewImporter connects to the datastore described by conf, imported comments are checked against limits
+func NewImporter(conf DBConfig, limits Limits) (*Importer, error) {
+	if conf.ConnString == "" {
+		return nil, errors.New("database connection string is required")
+	}
+
+	if err := limits.validate(); err != nil {
+		return nil, err
+	}
+
+	db, err := newDB(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Importer{instrumentedDatastore{db}, limits}, nil
+}
+
+// Import writes comments of tenant in a single transaction and returns number of written comments.
+// Comments whose UID already exists are left intact. UIDs, authors, targets, parents and timestamps
+// are taken from comments as is, so parents must be imported along with or before their replies.
+// Comments are checked as created ones, nothing is written if any of them is invalid.
+func (i *Importer) Import(ctx context.Context, tenant string, comments []*Comment) (int64, error) {
+	for _, c := range comments {
+		if err := i.validate(c); err != nil {
+			return 0, fmt.Errorf("comment %s: %v", c.UID, err)
+		}
+	}
+
+	return i.db.importComments(withTenant(ctx, tenant), comments)
+}
+
+// validate checks comment as CreateComment does, normalizing its target
+func (i *Importer) validate(c *Comment) error {
+	target, err := targetOf("", c.Target.Type, c.Target.ID)
+	if err != nil {
+		return err
+	}
+
+	c.Target = target
+	switch {
+	case c.GuestName != "" && c.UserUID != uuid.Nil:
+		return errors.New("guest comment must have nil user")
+	case c.GuestName == "" && c.UserUID == uuid.Nil:
+		return errors.New("author is required")
+	case c.UserUID == erasedUserUID && !c.IsDeleted:
+		return errors.New("comment of erased user must be removed")
+	}
+
+	if i.limits.bodyTooLong(c.Body) {
+		return errBodyTooLong
+	}
+
+	return nil
+}
+
+// Close closes connections to the datastore
+func (i *Importer) Close() error {
+	return i.db.close()
+}
+
+func (db *db) importComments(ctx context.Context, comments []*Comment) (int64, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fromPostgres(err)
+	}
+
+	defer tx.Rollback()
+
+	query := `INSERT INTO comments (uid, tenant, user_uid, resource_type, resource_id, body, parent_uid, created_at, modified_at, is_deleted,
+		guest_name, is_pending, removed_by, removal_reason, removal_notes, removed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16) ON CONFLICT (uid) DO NOTHING`
+
+	var total int64
+	for _, c := range comments {
+		// comments removed at unknown time count as removed when last modified, as migrated removals do
+		removedAt := pq.NullTime{Time: c.Removal.At, Valid: c.IsDeleted}
+		if removedAt.Time.IsZero() {
+			removedAt.Time = c.ModifiedAt
+		}
+
+		nRows, err := execContext(ctx, tx, "importComments", query, c.UID.String(), tenant, c.UserUID.String(), c.Target.Type, c.Target.ID, c.Body,
+			c.ParentUID.String(), c.CreatedAt, c.ModifiedAt, c.IsDeleted, c.GuestName, c.IsPending, c.Removal.By, c.Removal.Reason, c.Removal.Notes, removedAt)
+		if err != nil {
+			return 0, err
+		}
+
+		total += nRows
+	}
+
+	return total, fromPostgres(tx.Commit())
+}
@@ -0,0 +1,92 @@
+package comment
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+)
+
+func TestImportUID(t *testing.T) {
+	if ImportUID("default", "disqus", "1") != ImportUID("default", "disqus", "1") {
+		t.Errorf("expected stable UID")
+	}
+
+	for _, uid := range []uuid.UUID{ImportUID("other", "disqus", "1"), ImportUID("default", "wordpress", "1"), ImportUID("default", "disqus", "2")} {
+		if uid == ImportUID("default", "disqus", "1") {
+			t.Errorf("expected different UIDs of different comments")
+		}
+	}
+}
+
+func TestImportValidation(t *testing.T) {
+	i := &Importer{&mockdb{}, Limits{MaxBodyLength: 10}}
+	target := postTarget(uuid.New())
+	valid := []*Comment{
+		{UID: uuid.New(), UserUID: uuid.New(), Target: Target{postType, strings.ToUpper(target.ID)}, Body: "body"},
+		{UID: uuid.New(), Target: target, Body: "guest", GuestName: "guest"},
+	}
+
+	nRows, err := i.Import(context.Background(), "default", valid)
+	if err != nil || nRows != 2 {
+		t.Fatalf("expected 2 imported comments, got %d, %v", nRows, err)
+	}
+
+	if valid[0].Target != target {
+		t.Errorf("expected normalized target %v, got %v", target, valid[0].Target)
+	}
+
+	invalid := map[string]*Comment{
+		"invalid post UID":   {UID: uuid.New(), UserUID: uuid.New(), Target: Target{postType, "post"}, Body: "body"},
+		"invalid type":       {UID: uuid.New(), UserUID: uuid.New(), Target: Target{"Post!", "1"}, Body: "body"},
+		"missing author":     {UID: uuid.New(), Target: target, Body: "body"},
+		"guest with user":    {UID: uuid.New(), UserUID: uuid.New(), Target: target, Body: "body", GuestName: "guest"},
+		"visible erased":     {UID: uuid.New(), UserUID: erasedUserUID, Target: target, Body: "body"},
+		"body exceeds limit": {UID: uuid.New(), UserUID: uuid.New(), Target: target, Body: "too long body"},
+	}
+
+	for name, c := range invalid {
+		if _, err := i.Import(context.Background(), "default", append(valid, c)); err == nil {
+			t.Errorf("%s: expected error, got nothing", name)
+		}
+	}
+}
+
+func TestImportComments(t *testing.T) {
+	d := openTestDB(t)
+	defer d.close()
+
+	ctx := withTenant(context.Background(), "import-"+uuid.New().String())
+	target := postTarget(uuid.New())
+	createdAt := time.Date(2012, 3, 5, 16, 51, 55, 0, time.UTC)
+	root := &Comment{UID: uuid.New(), UserUID: uuid.New(), Target: target, Body: "root", CreatedAt: createdAt, ModifiedAt: createdAt}
+	removed := &Comment{UID: uuid.New(), UserUID: uuid.New(), Target: target, Body: "removed", ParentUID: root.UID,
+		CreatedAt: createdAt, ModifiedAt: createdAt, IsDeleted: true, Removal: RemovedAtSource()}
+
+	nRows, err := d.importComments(ctx, []*Comment{root, removed})
+	if err != nil || nRows != 2 {
+		t.Fatalf("expected 2 imported comments, got %d, %v", nRows, err)
+	}
+
+	nRows, err = d.importComments(ctx, []*Comment{root, removed})
+	if err != nil || nRows != 0 {
+		t.Errorf("expected repeated import to write nothing, got %d, %v", nRows, err)
+	}
+
+	c, err := d.getOne(ctx, root.UID)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !c.CreatedAt.Equal(createdAt) || c.UserUID != root.UserUID || c.Body != "root" {
+		t.Errorf("expected comment imported as is, got %+v", c)
+	}
+
+	comments, err := d.getRemoved(ctx, reasonRemovedAtSource, 10, 0)
+	if err != nil || len(comments) != 1 || comments[0].UID != removed.UID || comments[0].Removal.By != removedBySystem ||
+		!comments[0].Removal.At.Equal(createdAt) {
+		t.Errorf("expected removed comment, got %v, %v", comments, err)
+	}
+}
@@ -0,0 +1,78 @@
+package comment
+
+import (
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// chainUnaryServer combines interceptors into one, first interceptor is the outermost
+func chainUnaryServer(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+
+		return chained(ctx, req)
+	}
+}
+
+// chainStreamServer combines interceptors into one, first interceptor is the outermost
+func chainStreamServer(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(srv interface{}, stream grpc.ServerStream) error {
+				return interceptor(srv, stream, info, next)
+			}
+		}
+
+		return chained(srv, stream)
+	}
+}
+
+// healthMethodPrefix prefixes methods of grpc.health.v1.Health
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// exceptHealthUnary skips interceptor for health checks, so probes without client certificate or tenant can call them
+func exceptHealthUnary(interceptor grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+			return handler(ctx, req)
+		}
+
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+// exceptHealthStream skips interceptor for health watches, so probes without client certificate or tenant can call them
+func exceptHealthStream(interceptor grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+			return handler(srv, stream)
+		}
+
+		return interceptor(srv, stream, info, handler)
+	}
+}
+
+// serverStream overrides context of wrapped stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// methodName returns short method name from full method string /package.service/method
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
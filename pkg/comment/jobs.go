@@ -0,0 +1,102 @@
+package comment
+
+import (
+	"hash/fnv"
+	"log"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// job is a periodic background task, replicas of the service take turns to run it
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// jobScheduler runs jobs on their intervals, a Postgres advisory lock makes sure only one replica runs a job at a time
+type jobScheduler struct {
+	db   *db
+	jobs []job
+}
+
+// jobLockID returns key of advisory lock of job
+func jobLockID(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("comment-job:" + name))
+	return int64(h.Sum64())
+}
+
+// start runs every job on its interval until stop is closed, running jobs are canceled on stop
+func (s *jobScheduler) start(stop <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	for _, j := range s.jobs {
+		go s.schedule(ctx, j)
+	}
+}
+
+func (s *jobScheduler) schedule(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		s.runOnce(ctx, j)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs job unless another replica is running it and records the result
+func (s *jobScheduler) runOnce(ctx context.Context, j job) {
+	start := time.Now()
+	ran, err := s.db.runLocked(ctx, jobLockID(j.name), j.run)
+	switch {
+	case err != nil:
+		log.Printf("job %s failed: %v", j.name, err)
+		jobRuns.WithLabelValues(j.name, "error").Inc()
+	case !ran:
+		jobRuns.WithLabelValues(j.name, "skipped").Inc()
+		return
+	default:
+		jobRuns.WithLabelValues(j.name, "success").Inc()
+		jobLastSuccess.WithLabelValues(j.name).Set(float64(time.Now().Unix()))
+	}
+
+	jobDuration.WithLabelValues(j.name).Observe(time.Since(start).Seconds())
+}
+
+// runLocked runs f holding advisory lock lockID and reports whether f ran, f doesn't run if the lock is held elsewhere.
+// Session level lock belongs to a dedicated connection, so it is released even if the connection breaks.
+func (db *db) runLocked(ctx context.Context, lockID int64, f func(context.Context) error) (bool, error) {
+	conn, err := db.DB.Conn(ctx)
+	if err != nil {
+		return false, fromPostgres(err)
+	}
+
+	defer conn.Close()
+
+	var locked bool
+	err = queryRow(ctx, conn, "job.lock", []interface{}{&locked}, "SELECT pg_try_advisory_lock($1)", lockID)
+	if err != nil || !locked {
+		return false, err
+	}
+
+	defer func() {
+		// ctx may be canceled already, the lock must be released anyway
+		var unlocked bool
+		err := queryRow(context.Background(), conn, "job.unlock", []interface{}{&unlocked}, "SELECT pg_advisory_unlock($1)", lockID)
+		if err != nil {
+			log.Printf("releasing job lock %d failed: %v", lockID, err)
+		}
+	}()
+
+	return true, f(ctx)
+}
@@ -0,0 +1,138 @@
+package comment
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	opentracing "github.com/opentracing/opentracing-go"
+	jaeger "github.com/uber/jaeger-client-go"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDHeader is the metadata key carrying request correlation ID
+const requestIDHeader = "x-request-id"
+
+// requestLog receives one JSON object per line for every handled RPC
+var requestLog = log.New(os.Stdout, "", 0)
+
+type requestIDKey struct{}
+
+// internalErr hides cause of internal error from clients, so it's only written to request log
+type internalErr struct {
+	cause error
+}
+
+func internalError(err error) error {
+	return &internalErr{err}
+}
+
+func (e *internalErr) Error() string {
+	return "internal error: " + e.cause.Error()
+}
+
+// GRPCStatus is used by grpc to build status sent to client
+func (e *internalErr) GRPCStatus() *status.Status {
+	return status.New(codes.Internal, "internal error")
+}
+
+type logEntry struct {
+	Time       string  `json:"time"`
+	Method     string  `json:"method"`
+	Code       string  `json:"code"`
+	DurationMs float64 `json:"duration_ms"`
+	RequestID  string  `json:"request_id"`
+	TraceID    string  `json:"trace_id,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// RequestIDFromContext returns correlation ID of current request
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// withRequestID takes request ID from incoming metadata or generates a new one
+func withRequestID(ctx context.Context) (context.Context, string) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	return context.WithValue(ctx, requestIDKey{}, requestID), requestID
+}
+
+func traceID(ctx context.Context) string {
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return ""
+	}
+
+	if spanContext, ok := span.Context().(jaeger.SpanContext); ok {
+		return spanContext.TraceID().String()
+	}
+
+	return ""
+}
+
+// logRequest writes request log entry and returns error safe to send to client
+func logRequest(ctx context.Context, fullMethod string, start time.Time, err error) error {
+	entry := logEntry{
+		Time:       start.UTC().Format(time.RFC3339Nano),
+		Method:     fullMethod,
+		Code:       status.Code(err).String(),
+		DurationMs: float64(time.Since(start)) / float64(time.Millisecond),
+		RequestID:  RequestIDFromContext(ctx),
+		TraceID:    traceID(ctx),
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	switch e := err.(type) {
+	case *internalErr:
+		err = e.GRPCStatus().Err()
+	case *domainError:
+		err = e.GRPCStatus().Err()
+	}
+
+	line, marshalErr := json.Marshal(entry)
+	if marshalErr != nil {
+		log.Printf("marshaling request log entry failed: %v", marshalErr)
+		return err
+	}
+
+	requestLog.Println(string(line))
+	return err
+}
+
+func loggingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx, requestID := withRequestID(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+
+	res, err := handler(ctx, req)
+	return res, logRequest(ctx, info.FullMethod, start, err)
+}
+
+func loggingStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, requestID := withRequestID(stream.Context())
+	stream.SetHeader(metadata.Pairs(requestIDHeader, requestID))
+
+	err := handler(srv, &serverStream{stream, ctx})
+	return logRequest(ctx, info.FullMethod, start, err)
+}
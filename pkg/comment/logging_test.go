@@ -0,0 +1,76 @@
+package comment
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func captureRequestLog() (*bytes.Buffer, func()) {
+	buf := new(bytes.Buffer)
+	old := requestLog
+	requestLog = log.New(buf, "", 0)
+	return buf, func() { requestLog = old }
+}
+
+func TestLoggingHidesInternalError(t *testing.T) {
+	buf, restore := captureRequestLog()
+	defer restore()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/comment.Comment/GetComment"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, internalError(errDummy)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "req-1"))
+	_, err := loggingUnaryInterceptor(ctx, nil, info, handler)
+	if status.Code(err) != codes.Internal {
+		t.Errorf("unexpected code: got %v want %v", status.Code(err), codes.Internal)
+	}
+
+	if strings.Contains(err.Error(), errDummy.Error()) {
+		t.Errorf("internal error details leaked to client: %v", err)
+	}
+
+	var entry logEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid log entry %q: %v", buf.String(), err)
+	}
+
+	if entry.RequestID != "req-1" {
+		t.Errorf("unexpected request ID: got %v want %v", entry.RequestID, "req-1")
+	}
+
+	if !strings.Contains(entry.Error, errDummy.Error()) {
+		t.Errorf("error cause not logged: %v", entry.Error)
+	}
+
+	if entry.Code != codes.Internal.String() {
+		t.Errorf("unexpected code: got %v want %v", entry.Code, codes.Internal.String())
+	}
+}
+
+func TestRequestIDGenerated(t *testing.T) {
+	_, restore := captureRequestLog()
+	defer restore()
+
+	var requestID string
+	info := &grpc.UnaryServerInfo{FullMethod: "/comment.Comment/GetComment"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		requestID = RequestIDFromContext(ctx)
+		return nil, nil
+	}
+
+	loggingUnaryInterceptor(context.Background(), nil, info, handler)
+	if requestID == "" {
+		t.Errorf("request ID not generated")
+	}
+}
@@ -0,0 +1,300 @@
+package comment
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const metricsNamespace = "comment"
+
+var (
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "grpc_requests_total",
+		Help:      "Number of handled RPCs by method and status code.",
+	}, []string{"method", "code"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "RPC latency by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	datastoreDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "datastore_duration_seconds",
+		Help:      "Datastore call latency by method and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "result"})
+
+	cacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cache_hits_total",
+		Help:      "Number of datastore calls served from cache by method.",
+	}, []string{"method"})
+
+	cacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cache_misses_total",
+		Help:      "Number of datastore calls not found in cache by method.",
+	}, []string{"method"})
+
+	cacheEvictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cache_evictions_total",
+		Help:      "Number of cache entries evicted because of size limit.",
+	})
+
+	cacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "cache_entries",
+		Help:      "Number of cached datastore results.",
+	})
+
+	jobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "job_runs_total",
+		Help:      "Number of background job runs by job and result, skipped runs were locked by another replica.",
+	}, []string{"job", "result"})
+
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "job_duration_seconds",
+		Help:      "Background job run duration by job.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 4, 8),
+	}, []string{"job"})
+
+	jobLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "job_last_success_timestamp_seconds",
+		Help:      "Unix time of last successful run of background job on this replica.",
+	}, []string{"job"})
+
+	retentionRows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "retention_rows_total",
+		Help:      "Number of rows changed by retention purge by action, dry run rows are only counted.",
+	}, []string{"action", "dry_run"})
+)
+
+func init() {
+	prometheus.MustRegister(rpcRequests, rpcDuration, datastoreDuration, cacheHits, cacheMisses, cacheEvictions, cacheEntries)
+	prometheus.MustRegister(jobRuns, jobDuration, jobLastSuccess, retentionRows)
+}
+
+func observeRPC(fullMethod string, start time.Time, err error) {
+	method := methodName(fullMethod)
+	rpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+	observeRPC(info.FullMethod, start, err)
+	return res, err
+}
+
+func metricsStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	observeRPC(info.FullMethod, start, err)
+	return err
+}
+
+// dbStatsCollector exports connection pool statistics of sql.DB
+type dbStatsCollector struct {
+	db *sql.DB
+
+	maxOpen           *prometheus.Desc
+	open              *prometheus.Desc
+	inUse             *prometheus.Desc
+	idle              *prometheus.Desc
+	waitCount         *prometheus.Desc
+	waitDuration      *prometheus.Desc
+	maxIdleClosed     *prometheus.Desc
+	maxLifetimeClosed *prometheus.Desc
+}
+
+func newDBStatsCollector(db *sql.DB) *dbStatsCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "db", name), help, nil, nil)
+	}
+
+	return &dbStatsCollector{
+		db:                db,
+		maxOpen:           desc("max_open_connections", "Maximum number of open connections to the database."),
+		open:              desc("open_connections", "Number of established connections both in use and idle."),
+		inUse:             desc("in_use_connections", "Number of connections currently in use."),
+		idle:              desc("idle_connections", "Number of idle connections."),
+		waitCount:         desc("wait_count_total", "Total number of connections waited for."),
+		waitDuration:      desc("wait_duration_seconds_total", "Total time blocked waiting for a new connection."),
+		maxIdleClosed:     desc("max_idle_closed_total", "Total number of connections closed due to SetMaxIdleConns."),
+		maxLifetimeClosed: desc("max_lifetime_closed_total", "Total number of connections closed due to SetConnMaxLifetime."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+	ch <- c.maxIdleClosed
+	ch <- c.maxLifetimeClosed
+}
+
+// Collect implements prometheus.Collector
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.db.Stats()
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.maxIdleClosed, prometheus.CounterValue, float64(stats.MaxIdleClosed))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeClosed, prometheus.CounterValue, float64(stats.MaxLifetimeClosed))
+}
+
+// instrumentedDatastore records latency of every call to wrapped datastore
+type instrumentedDatastore struct {
+	next datastore
+}
+
+func observeDatastore(method string, start time.Time, err error) {
+	result := "ok"
+	if err != nil && err != errNotFound {
+		result = "error"
+	}
+
+	datastoreDuration.WithLabelValues(method, result).Observe(time.Since(start).Seconds())
+}
+
+func (ds instrumentedDatastore) getAll(ctx context.Context, target Target, parentUID uuid.UUID, pageSize, pageNumber int32) (result []*Comment, err error) {
+	defer func(start time.Time) { observeDatastore("getAll", start, err) }(time.Now())
+	return ds.next.getAll(ctx, target, parentUID, pageSize, pageNumber)
+}
+
+func (ds instrumentedDatastore) getOne(ctx context.Context, uid uuid.UUID) (result *Comment, err error) {
+	defer func(start time.Time) { observeDatastore("getOne", start, err) }(time.Now())
+	return ds.next.getOne(ctx, uid)
+}
+
+func (ds instrumentedDatastore) create(ctx context.Context, target Target, body string, parentUID, userUID uuid.UUID, guest *Guest) (result *Comment, err error) {
+	defer func(start time.Time) { observeDatastore("create", start, err) }(time.Now())
+	return ds.next.create(ctx, target, body, parentUID, userUID, guest)
+}
+
+func (ds instrumentedDatastore) update(ctx context.Context, uid uuid.UUID, changes commentChanges) (result *Comment, err error) {
+	defer func(start time.Time) { observeDatastore("update", start, err) }(time.Now())
+	return ds.next.update(ctx, uid, changes)
+}
+
+func (ds instrumentedDatastore) removeContent(ctx context.Context, uid uuid.UUID, removal Removal) (err error) {
+	defer func(start time.Time) { observeDatastore("removeContent", start, err) }(time.Now())
+	return ds.next.removeContent(ctx, uid, removal)
+}
+
+func (ds instrumentedDatastore) delete(ctx context.Context, uid uuid.UUID, mode deleteMode, removal Removal) (result int64, err error) {
+	defer func(start time.Time) { observeDatastore("delete", start, err) }(time.Now())
+	return ds.next.delete(ctx, uid, mode, removal)
+}
+
+func (ds instrumentedDatastore) getOwner(ctx context.Context, uid uuid.UUID) (result string, err error) {
+	defer func(start time.Time) { observeDatastore("getOwner", start, err) }(time.Now())
+	return ds.next.getOwner(ctx, uid)
+}
+
+func (ds instrumentedDatastore) getAllByUser(ctx context.Context, userUID uuid.UUID) (result []*Comment, err error) {
+	defer func(start time.Time) { observeDatastore("getAllByUser", start, err) }(time.Now())
+	return ds.next.getAllByUser(ctx, userUID)
+}
+
+func (ds instrumentedDatastore) getRevisionsByUser(ctx context.Context, userUID uuid.UUID) (result []*Revision, err error) {
+	defer func(start time.Time) { observeDatastore("getRevisionsByUser", start, err) }(time.Now())
+	return ds.next.getRevisionsByUser(ctx, userUID)
+}
+
+func (ds instrumentedDatastore) eraseUser(ctx context.Context, userUID uuid.UUID) (result int64, err error) {
+	defer func(start time.Time) { observeDatastore("eraseUser", start, err) }(time.Now())
+	return ds.next.eraseUser(ctx, userUID)
+}
+
+func (ds instrumentedDatastore) deleteForTarget(ctx context.Context, target Target, batchSize int32) (result int64, err error) {
+	defer func(start time.Time) { observeDatastore("deleteForTarget", start, err) }(time.Now())
+	return ds.next.deleteForTarget(ctx, target, batchSize)
+}
+
+func (ds instrumentedDatastore) restoreContent(ctx context.Context, uid uuid.UUID) (err error) {
+	defer func(start time.Time) { observeDatastore("restoreContent", start, err) }(time.Now())
+	return ds.next.restoreContent(ctx, uid)
+}
+
+func (ds instrumentedDatastore) removeContentForTarget(ctx context.Context, target Target, batchSize int32) (result int64, err error) {
+	defer func(start time.Time) { observeDatastore("removeContentForTarget", start, err) }(time.Now())
+	return ds.next.removeContentForTarget(ctx, target, batchSize)
+}
+
+func (ds instrumentedDatastore) getPending(ctx context.Context, pageSize, pageNumber int32) (result []*Comment, err error) {
+	defer func(start time.Time) { observeDatastore("getPending", start, err) }(time.Now())
+	return ds.next.getPending(ctx, pageSize, pageNumber)
+}
+
+func (ds instrumentedDatastore) getRemoved(ctx context.Context, reason string, pageSize, pageNumber int32) (result []*Comment, err error) {
+	defer func(start time.Time) { observeDatastore("getRemoved", start, err) }(time.Now())
+	return ds.next.getRemoved(ctx, reason, pageSize, pageNumber)
+}
+
+func (ds instrumentedDatastore) approve(ctx context.Context, uid uuid.UUID) (result *Comment, err error) {
+	defer func(start time.Time) { observeDatastore("approve", start, err) }(time.Now())
+	return ds.next.approve(ctx, uid)
+}
+
+func (ds instrumentedDatastore) claimGuest(ctx context.Context, emailHash string, userUID uuid.UUID) (result int64, err error) {
+	defer func(start time.Time) { observeDatastore("claimGuest", start, err) }(time.Now())
+	return ds.next.claimGuest(ctx, emailHash, userUID)
+}
+
+func (ds instrumentedDatastore) importComments(ctx context.Context, comments []*Comment) (result int64, err error) {
+	defer func(start time.Time) { observeDatastore("importComments", start, err) }(time.Now())
+	return ds.next.importComments(ctx, comments)
+}
+
+func (ds instrumentedDatastore) saveDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID, body string, notBefore time.Time) (result *Draft, err error) {
+	defer func(start time.Time) { observeDatastore("saveDraft", start, err) }(time.Now())
+	return ds.next.saveDraft(ctx, userUID, target, parentUID, body, notBefore)
+}
+
+func (ds instrumentedDatastore) getDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID, notBefore time.Time) (result *Draft, err error) {
+	defer func(start time.Time) { observeDatastore("getDraft", start, err) }(time.Now())
+	return ds.next.getDraft(ctx, userUID, target, parentUID, notBefore)
+}
+
+func (ds instrumentedDatastore) getDrafts(ctx context.Context, userUID uuid.UUID, notBefore time.Time, pageSize, pageNumber int32) (result []*Draft, err error) {
+	defer func(start time.Time) { observeDatastore("getDrafts", start, err) }(time.Now())
+	return ds.next.getDrafts(ctx, userUID, notBefore, pageSize, pageNumber)
+}
+
+func (ds instrumentedDatastore) deleteDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID) (err error) {
+	defer func(start time.Time) { observeDatastore("deleteDraft", start, err) }(time.Now())
+	return ds.next.deleteDraft(ctx, userUID, target, parentUID)
+}
+
+func (ds instrumentedDatastore) ping(ctx context.Context) (err error) {
+	defer func(start time.Time) { observeDatastore("ping", start, err) }(time.Now())
+	return ds.next.ping(ctx)
+}
+
+func (ds instrumentedDatastore) close() error {
+	return ds.next.close()
+}
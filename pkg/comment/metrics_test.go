@@ -0,0 +1,43 @@
+package comment
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsUnaryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/comment.Comment/GetComment"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errNotFound
+	}
+
+	counter := rpcRequests.WithLabelValues("GetComment", codes.NotFound.String())
+	before := testutil.ToFloat64(counter)
+	_, err := metricsUnaryInterceptor(context.Background(), nil, info, handler)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if after := testutil.ToFloat64(counter); after != before+1 {
+		t.Errorf("unexpected request count: got %v want %v", after, before+1)
+	}
+}
+
+func TestInstrumentedDatastore(t *testing.T) {
+	ds := instrumentedDatastore{&mockdb{}}
+	_, err := ds.getOne(context.Background(), uuid.Nil)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	_, err = ds.getOne(context.Background(), dummyUID)
+	if err != errDummy {
+		t.Errorf("unexpected error: got %v want %v", err, errDummy)
+	}
+}
@@ -3,197 +3,410 @@ package comment
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	"golang.org/x/net/context"
 )
 
 var (
 	errNotCreated = errors.New("comment not created")
-	errNotFound   = errors.New("comment not found")
+	errNotFound   = notFound("comment", "comment not found")
+	errNoParent   = notFound("comment", "parent comment not found")
+	errHasReplies = preconditionFailed("REPLIES", "comment has replies")
+	errBadMode    = invalidArgument("mode", "invalid delete mode")
+
+	errDraftNotFound = notFound("draft", "draft not found")
+)
+
+// deleteMode describes what to do with replies of deleted comment
+type deleteMode int
+
+const (
+	// deleteRejectIfReplies refuses to delete comment which has replies
+	deleteRejectIfReplies deleteMode = iota
+	// deleteCascade deletes comment with all its replies
+	deleteCascade
+	// deleteTombstone removes content of comment which has replies and deletes it otherwise
+	deleteTombstone
 )
 
+// erasedUserUID replaces user UID of comments whose author was erased. It differs from nil UUID
+// which is the user UID of guest comments, and is never accepted as UID of a user.
+var erasedUserUID = uuid.Must(uuid.Parse("ffffffff-ffff-ffff-ffff-ffffffffffff"))
+
+const commentColumns = "uid, user_uid, resource_type, resource_id, body, parent_uid, created_at, modified_at, is_deleted, guest_name, is_pending, removed_by, removal_reason, removal_notes, removed_at"
+
+// postType is the resource type of posts, postUid fields of requests are aliases of targets of this type
+const postType = "post"
+
+// Target is a resource comments are attached to
+type Target struct {
+	Type string
+	ID   string
+}
+
+func (t Target) String() string {
+	return t.Type + "/" + t.ID
+}
+
 // Comment describes comment to a post
 type Comment struct {
 	UID        uuid.UUID
 	UserUID    uuid.UUID
-	PostUID    uuid.UUID
+	Target     Target
 	Body       string
 	ParentUID  uuid.UUID
 	CreatedAt  time.Time
 	ModifiedAt time.Time
 	IsDeleted  bool
+	// GuestName is the display name of author without an account, such comments have nil UserUID
+	GuestName string
+	// IsPending comments are hidden until approved by moderator
+	IsPending bool
+	// Removal describes removal of deleted comment
+	Removal Removal
 }
 
-type datastore interface {
-	getAll(uuid.UUID, uuid.UUID, int32, int32) ([]*Comment, error)
-	getOne(uuid.UUID) (*Comment, error)
-	create(uuid.UUID, string, uuid.UUID, uuid.UUID) (*Comment, error)
-	update(uuid.UUID, string) error
-	removeContent(uuid.UUID) error
-	delete(uuid.UUID) error
-	getOwner(uuid.UUID) (string, error)
+const (
+	removedByAuthor    = "author"
+	removedByModerator = "moderator"
+	removedBySystem    = "system"
+)
+
+// Removal tells who removed content of a comment and why, By is empty if it's unknown
+type Removal struct {
+	By string
+	// Reason is a code from removal reason catalogue
+	Reason string
+	Notes  string
+	// At is the time of removal, datastore sets it
+	At time.Time
 }
 
-type db struct {
-	*sql.DB
+// Guest describes author of comment written without an account
+type Guest struct {
+	Name string
+	// EmailHash lets guest claim comment after registering, empty if guest left no email
+	EmailHash string
+	// ChallengeUID is the solved challenge, every challenge can be used for one comment only
+	ChallengeUID uuid.UUID
+	// Pending comments are hidden until approved by moderator
+	Pending bool
 }
 
-func newDB(connString string) (*db, error) {
-	postgres, err := sql.Open("postgres", connString)
-	return &db{postgres}, err
+// commentChanges are fields changed by update, nil fields are left as is
+type commentChanges struct {
+	body *string
+}
+
+// Revision describes previous version of comment body
+type Revision struct {
+	UID        uuid.UUID
+	CommentUID uuid.UUID
+	Body       string
+	CreatedAt  time.Time
 }
 
-func (db *db) getAll(postUID uuid.UUID, parentUID uuid.UUID, pageSize, pageNumber int32) ([]*Comment, error) {
-	query := "SELECT * FROM comments WHERE post_uid=$1 AND parent_uid=$2 ORDER BY created_at DESC LIMIT $3 OFFSET $4"
+// Draft is an unpublished comment of user, user has at most one draft per target and parent
+type Draft struct {
+	UserUID    uuid.UUID
+	Target     Target
+	ParentUID  uuid.UUID
+	Body       string
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
 
-	lastRecord := pageNumber * pageSize
-	rows, err := db.Query(query, postUID.String(), parentUID.String(), pageSize, lastRecord)
+const draftColumns = "user_uid, resource_type, resource_id, parent_uid, body, created_at, modified_at"
+
+type datastore interface {
+	getAll(context.Context, Target, uuid.UUID, int32, int32) ([]*Comment, error)
+	getOne(context.Context, uuid.UUID) (*Comment, error)
+	create(context.Context, Target, string, uuid.UUID, uuid.UUID, *Guest) (*Comment, error)
+	update(context.Context, uuid.UUID, commentChanges) (*Comment, error)
+	removeContent(context.Context, uuid.UUID, Removal) error
+	restoreContent(context.Context, uuid.UUID) error
+	delete(context.Context, uuid.UUID, deleteMode, Removal) (int64, error)
+	getOwner(context.Context, uuid.UUID) (string, error)
+	getAllByUser(context.Context, uuid.UUID) ([]*Comment, error)
+	getRevisionsByUser(context.Context, uuid.UUID) ([]*Revision, error)
+	eraseUser(context.Context, uuid.UUID) (int64, error)
+	deleteForTarget(context.Context, Target, int32) (int64, error)
+	removeContentForTarget(context.Context, Target, int32) (int64, error)
+	getPending(context.Context, int32, int32) ([]*Comment, error)
+	getRemoved(context.Context, string, int32, int32) ([]*Comment, error)
+	approve(context.Context, uuid.UUID) (*Comment, error)
+	claimGuest(context.Context, string, uuid.UUID) (int64, error)
+	importComments(context.Context, []*Comment) (int64, error)
+	saveDraft(context.Context, uuid.UUID, Target, uuid.UUID, string, time.Time) (*Draft, error)
+	getDraft(context.Context, uuid.UUID, Target, uuid.UUID, time.Time) (*Draft, error)
+	getDrafts(context.Context, uuid.UUID, time.Time, int32, int32) ([]*Draft, error)
+	deleteDraft(context.Context, uuid.UUID, Target, uuid.UUID) error
+	ping(context.Context) error
+	close() error
+}
+
+// db is the Postgres datastore, writes go to primary and reads may go to replicas
+type db struct {
+	*sql.DB
+	replicas    []*replica
+	nextReplica uint32
+}
+
+func newDB(conf DBConfig) (*db, error) {
+	primary, err := openPostgres(conf.ConnString, conf)
 	if err != nil {
 		return nil, err
 	}
 
-	defer rows.Close()
-	result := make([]*Comment, 0)
-	for rows.Next() {
-		comment := new(Comment)
-		var uid, userUID, pUID, parentUID string
-		err := rows.Scan(&uid, &userUID, &pUID, &comment.Body, &parentUID, &comment.CreatedAt, &comment.ModifiedAt, &comment.IsDeleted)
+	result := &db{DB: primary}
+	for i, connString := range conf.ReplicaConnStrings {
+		postgres, err := openPostgres(connString, conf)
 		if err != nil {
+			result.close()
 			return nil, err
 		}
 
-		comment.UID, err = uuid.Parse(uid)
-		if err != nil {
-			return nil, err
-		}
+		result.replicas = append(result.replicas, &replica{DB: postgres, name: fmt.Sprintf("#%d", i+1)})
+	}
 
-		comment.UserUID, err = uuid.Parse(userUID)
-		if err != nil {
-			return nil, err
-		}
+	return result, nil
+}
 
-		comment.PostUID, err = uuid.Parse(pUID)
-		if err != nil {
-			return nil, err
-		}
+func openPostgres(connString string, conf DBConfig) (*sql.DB, error) {
+	postgres, err := sql.Open("postgres", connString)
+	if err != nil {
+		return nil, err
+	}
 
-		if parentUID != "" {
-			comment.ParentUID, err = uuid.Parse(parentUID)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			comment.ParentUID = uuid.Nil
-		}
+	postgres.SetMaxOpenConns(conf.MaxOpenConns)
+	postgres.SetMaxIdleConns(conf.MaxIdleConns)
+	postgres.SetConnMaxLifetime(conf.ConnMaxLifetime)
+	return postgres, nil
+}
 
-		result = append(result, comment)
+func (db *db) getAll(ctx context.Context, target Target, parentUID uuid.UUID, pageSize, pageNumber int32) ([]*Comment, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if err = rows.Err(); err != nil {
+	query := "SELECT " + commentColumns + " FROM comments WHERE tenant=$1 AND resource_type=$2 AND resource_id=$3 AND parent_uid=$4 AND is_pending=false ORDER BY created_at DESC LIMIT $5 OFFSET $6"
+
+	lastRecord := pageNumber * pageSize
+	return queryComments(ctx, db.reader(ctx), "getAll", query, tenant, target.Type, target.ID, parentUID.String(), pageSize, lastRecord)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanComment(row scanner) (*Comment, error) {
+	comment := new(Comment)
+	var uid, userUID string
+	var parentUID sql.NullString
+	var removedAt pq.NullTime
+	err := row.Scan(&uid, &userUID, &comment.Target.Type, &comment.Target.ID, &comment.Body, &parentUID, &comment.CreatedAt, &comment.ModifiedAt, &comment.IsDeleted, &comment.GuestName, &comment.IsPending,
+		&comment.Removal.By, &comment.Removal.Reason, &comment.Removal.Notes, &removedAt)
+	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
-}
+	comment.Removal.At = removedAt.Time
 
-func (db *db) getOne(uid uuid.UUID) (*Comment, error) {
-	query := "SELECT user_uid, post_uid, body, parent_uid, created_at, modified_at, is_deleted FROM comments WHERE uid=$1"
-	row := db.QueryRow(query, uid.String())
-	result := new(Comment)
-	var stringUserUID, stringPostUID, stringParentUID string
-	switch err := row.Scan(&stringUserUID, &stringPostUID, &result.Body, &stringParentUID, &result.CreatedAt, &result.ModifiedAt, &result.IsDeleted); err {
-	case nil:
-		result.UID = uid
-		userUID, err := uuid.Parse(stringUserUID)
-		if err != nil {
-			return nil, err
-		}
+	comment.UID, err = uuid.Parse(uid)
+	if err != nil {
+		return nil, err
+	}
 
-		result.UserUID = userUID
+	comment.UserUID, err = uuid.Parse(userUID)
+	if err != nil {
+		return nil, err
+	}
 
-		postUID, err := uuid.Parse(stringPostUID)
+	if parentUID.Valid && parentUID.String != "" {
+		comment.ParentUID, err = uuid.Parse(parentUID.String)
 		if err != nil {
 			return nil, err
 		}
+	} else {
+		comment.ParentUID = uuid.Nil
+	}
 
-		result.PostUID = postUID
+	return comment, nil
+}
 
-		parentUID, err := uuid.Parse(stringParentUID)
+// queryComments runs query selecting commentColumns and scans all returned comments
+func queryComments(ctx context.Context, q queryer, name, query string, args ...interface{}) ([]*Comment, error) {
+	result := make([]*Comment, 0)
+	err := queryRows(ctx, q, name, func(rows *sql.Rows) error {
+		comment, err := scanComment(rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		result.ParentUID = parentUID
-		return result, nil
-	case sql.ErrNoRows:
-		return nil, errNotFound
-	default:
+		result = append(result, comment)
+		return nil
+	}, query, args...)
+	if err != nil {
 		return nil, err
 	}
+
+	return result, nil
+}
+
+func (db *db) getOne(ctx context.Context, uid uuid.UUID) (*Comment, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + commentColumns + " FROM comments WHERE uid=$1 AND tenant=$2"
+	comments, err := queryComments(ctx, db.reader(ctx), "getOne", query, uid.String(), tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, errNotFound
+	}
+
+	return comments[0], nil
 }
 
-func (db *db) create(postUID uuid.UUID, body string, parentUID, userUID uuid.UUID) (*Comment, error) {
+// create creates comment of user or, if guest isn't nil, of guest
+func (db *db) create(ctx context.Context, target Target, body string, parentUID, userUID uuid.UUID, guest *Guest) (*Comment, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	comment := new(Comment)
 
-	query := "INSERT INTO comments (uid, user_uid, post_uid, body, parent_uid, created_at, modified_at) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fromPostgres(err)
+	}
+
+	defer tx.Rollback()
+
+	if parentUID != uuid.Nil {
+		// lock parent so it can't be deleted until reply is committed
+		query := "SELECT 1 FROM comments WHERE uid=$1 AND tenant=$2 AND resource_type=$3 AND resource_id=$4 AND is_pending=false FOR SHARE"
+		var exists int
+		switch err := queryRow(ctx, tx, "create.lockParent", []interface{}{&exists}, query, parentUID.String(), tenant, target.Type, target.ID); err {
+		case nil:
+		case sql.ErrNoRows:
+			return nil, errNoParent
+		default:
+			return nil, err
+		}
+	}
+
+	// challenges are unique, so a solved challenge can't be used for another comment
+	query := `INSERT INTO comments (uid, tenant, user_uid, resource_type, resource_id, body, parent_uid, created_at, modified_at, guest_name, guest_email_hash, challenge_uid, is_pending)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) ON CONFLICT (challenge_uid) DO NOTHING`
 
 	uid := uuid.New()
 	now := time.Now()
 
 	comment.UID = uid
 	comment.UserUID = userUID
-	comment.PostUID = postUID
+	comment.Target = target
 	comment.Body = body
 	comment.ParentUID = parentUID
 	comment.CreatedAt = now
 	comment.ModifiedAt = now
 
-	result, err := db.Exec(query, uid.String(), userUID.String(), postUID.String(), body, parentUID.String(), now, now)
-	if err != nil {
-		return nil, err
+	var emailHash sql.NullString
+	var challengeUID interface{}
+	if guest != nil {
+		comment.GuestName = guest.Name
+		comment.IsPending = guest.Pending
+		emailHash = sql.NullString{String: guest.EmailHash, Valid: guest.EmailHash != ""}
+		challengeUID = guest.ChallengeUID.String()
 	}
 
-	nRows, err := result.RowsAffected()
+	nRows, err := execContext(ctx, tx, "create.insert", query, uid.String(), tenant, userUID.String(), target.Type, target.ID, body, parentUID.String(), now, now,
+		comment.GuestName, emailHash, challengeUID, comment.IsPending)
 	if err != nil {
 		return nil, err
 	}
 
+	if nRows == 0 && guest != nil {
+		return nil, errChallengeUsed
+	}
+
 	if nRows == 0 {
 		return nil, errNotCreated
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, fromPostgres(err)
+	}
+
 	return comment, nil
 }
 
-func (db *db) update(uid uuid.UUID, body string) error {
-	query := "UPDATE comments SET body=$1, modified_at=$2 WHERE uid=$3 AND is_deleted=false"
-	result, err := db.Exec(query, body, time.Now(), uid.String())
+// update applies changes to comment and returns updated comment, previous body is saved as a revision
+func (db *db) update(ctx context.Context, uid uuid.UUID, changes commentChanges) (*Comment, error) {
+	tenant, err := tenantOf(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	nRows, err := result.RowsAffected()
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, fromPostgres(err)
 	}
 
-	if nRows == 0 {
-		return errNotFound
+	defer tx.Rollback()
+
+	var set []string
+	var args []interface{}
+	if changes.body != nil {
+		query := "INSERT INTO comment_revisions (uid, comment_uid, body, created_at) SELECT $1, uid, body, modified_at FROM comments WHERE uid=$2 AND tenant=$3 AND is_deleted=false"
+		_, err = execContext(ctx, tx, "update.saveRevision", query, uuid.New().String(), uid.String(), tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, *changes.body)
+		set = append(set, fmt.Sprintf("body=$%d", len(args)))
 	}
 
-	return nil
+	args = append(args, time.Now())
+	set = append(set, fmt.Sprintf("modified_at=$%d", len(args)))
+	args = append(args, uid.String(), tenant)
+	query := fmt.Sprintf("UPDATE comments SET %s WHERE uid=$%d AND tenant=$%d AND is_deleted=false RETURNING %s", strings.Join(set, ", "), len(args)-1, len(args), commentColumns)
+	comments, err := queryComments(ctx, tx, "update.apply", query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, errNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fromPostgres(err)
+	}
+
+	return comments[0], nil
 }
 
-func (db *db) removeContent(uid uuid.UUID) error {
-	query := "UPDATE comments SET is_deleted=true, modified_at=$1 WHERE uid=$2 AND is_deleted=false"
-	result, err := db.Exec(query, time.Now(), uid.String())
+func (db *db) removeContent(ctx context.Context, uid uuid.UUID, removal Removal) error {
+	tenant, err := tenantOf(ctx)
 	if err != nil {
 		return err
 	}
 
-	nRows, err := result.RowsAffected()
+	query := "UPDATE comments SET is_deleted=true, modified_at=$1, removed_at=$1, removed_by=$2, removal_reason=$3, removal_notes=$4 WHERE uid=$5 AND tenant=$6 AND is_deleted=false"
+	nRows, err := execContext(ctx, db, "removeContent", query, time.Now(), removal.By, removal.Reason, removal.Notes, uid.String(), tenant)
 	if err != nil {
 		return err
 	}
@@ -205,14 +418,16 @@ func (db *db) removeContent(uid uuid.UUID) error {
 	return nil
 }
 
-func (db *db) delete(uid uuid.UUID) error {
-	query := "DELETE FROM comments WHERE uid=$1"
-	result, err := db.Exec(query, uid.String())
+// restoreContent makes removed comment visible again, comments of erased users and comments
+// whose bodies were purged after retention period can't be restored.
+func (db *db) restoreContent(ctx context.Context, uid uuid.UUID) error {
+	tenant, err := tenantOf(ctx)
 	if err != nil {
 		return err
 	}
 
-	nRows, err := result.RowsAffected()
+	query := "UPDATE comments SET is_deleted=false, modified_at=$1, removed_by='', removal_reason='', removal_notes='', removed_at=NULL WHERE uid=$2 AND tenant=$3 AND is_deleted=true AND body<>'' AND user_uid<>$4"
+	nRows, err := execContext(ctx, db, "restoreContent", query, time.Now(), uid.String(), tenant, erasedUserUID.String())
 	if err != nil {
 		return err
 	}
@@ -224,11 +439,83 @@ func (db *db) delete(uid uuid.UUID) error {
 	return nil
 }
 
-func (db *db) getOwner(uid uuid.UUID) (string, error) {
-	query := "SELECT user_uid FROM comments WHERE uid=$1"
-	row := db.QueryRow(query, uid.String())
+// delete deletes comment according to mode and returns number of deleted rows.
+// Zero deleted rows with nil error means comment was turned into a tombstone, removal is recorded then.
+func (db *db) delete(ctx context.Context, uid uuid.UUID, mode deleteMode, removal Removal) (int64, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fromPostgres(err)
+	}
+
+	defer tx.Rollback()
+
+	// lock comment with its whole subtree so no new replies can be attached to it
+	query := `WITH RECURSIVE subtree AS (
+		SELECT uid FROM comments WHERE uid=$1 AND tenant=$2
+		UNION ALL
+		SELECT c.uid FROM comments c JOIN subtree s ON c.parent_uid = s.uid WHERE c.tenant=$2
+	) SELECT uid FROM comments WHERE uid IN (SELECT uid FROM subtree) FOR UPDATE`
+	var subtreeSize int
+	err = queryRows(ctx, tx, "delete.lockSubtree", func(*sql.Rows) error {
+		subtreeSize++
+		return nil
+	}, query, uid.String(), tenant)
+	if err != nil {
+		return 0, err
+	}
+
+	if subtreeSize == 0 {
+		return 0, errNotFound
+	}
+
+	hasReplies := subtreeSize > 1
+	var nRows int64
+	switch {
+	case !hasReplies:
+		query = "DELETE FROM comments WHERE uid=$1 AND tenant=$2"
+		nRows, err = execContext(ctx, tx, "delete.single", query, uid.String(), tenant)
+	case mode == deleteRejectIfReplies:
+		return 0, errHasReplies
+	case mode == deleteTombstone:
+		query = "UPDATE comments SET is_deleted=true, modified_at=$1, removed_at=$1, removed_by=$2, removal_reason=$3, removal_notes=$4 WHERE uid=$5 AND tenant=$6 AND is_deleted=false"
+		_, err = execContext(ctx, tx, "delete.tombstone", query, time.Now(), removal.By, removal.Reason, removal.Notes, uid.String(), tenant)
+		if err != nil {
+			return 0, err
+		}
+
+		return 0, fromPostgres(tx.Commit())
+	case mode == deleteCascade:
+		query = `WITH RECURSIVE subtree AS (
+			SELECT uid FROM comments WHERE uid=$1 AND tenant=$2
+			UNION ALL
+			SELECT c.uid FROM comments c JOIN subtree s ON c.parent_uid = s.uid WHERE c.tenant=$2
+		) DELETE FROM comments WHERE uid IN (SELECT uid FROM subtree)`
+		nRows, err = execContext(ctx, tx, "delete.cascade", query, uid.String(), tenant)
+	default:
+		return 0, errBadMode
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return nRows, fromPostgres(tx.Commit())
+}
+
+func (db *db) getOwner(ctx context.Context, uid uuid.UUID) (string, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := "SELECT user_uid FROM comments WHERE uid=$1 AND tenant=$2"
 	var result string
-	switch err := row.Scan(&result); err {
+	switch err := queryRow(ctx, db.reader(ctx), "getOwner", []interface{}{&result}, query, uid.String(), tenant); err {
 	case nil:
 		return result, nil
 	case sql.ErrNoRows:
@@ -237,3 +524,301 @@ func (db *db) getOwner(uid uuid.UUID) (string, error) {
 		return "", err
 	}
 }
+
+func (db *db) getAllByUser(ctx context.Context, userUID uuid.UUID) ([]*Comment, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + commentColumns + " FROM comments WHERE user_uid=$1 AND tenant=$2 ORDER BY created_at"
+	return queryComments(ctx, db, "getAllByUser", query, userUID.String(), tenant)
+}
+
+func (db *db) getRevisionsByUser(ctx context.Context, userUID uuid.UUID) ([]*Revision, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT r.uid, r.comment_uid, r.body, r.created_at FROM comment_revisions r JOIN comments c ON c.uid = r.comment_uid WHERE c.user_uid=$1 AND c.tenant=$2 ORDER BY r.created_at"
+	result := make([]*Revision, 0)
+	err = queryRows(ctx, db, "getRevisionsByUser", func(rows *sql.Rows) error {
+		revision := new(Revision)
+		var uid, commentUID string
+		err := rows.Scan(&uid, &commentUID, &revision.Body, &revision.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		revision.UID, err = uuid.Parse(uid)
+		if err != nil {
+			return err
+		}
+
+		revision.CommentUID, err = uuid.Parse(commentUID)
+		if err != nil {
+			return err
+		}
+
+		result = append(result, revision)
+		return nil
+	}, query, userUID.String(), tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// eraseUser redacts every comment of user and detaches them from the user, keeping replies in place.
+// Drafts of user are deleted.
+func (db *db) eraseUser(ctx context.Context, userUID uuid.UUID) (int64, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fromPostgres(err)
+	}
+
+	defer tx.Rollback()
+
+	query := "DELETE FROM comment_revisions WHERE comment_uid IN (SELECT uid FROM comments WHERE user_uid=$1 AND tenant=$2)"
+	_, err = execContext(ctx, tx, "eraseUser.deleteRevisions", query, userUID.String(), tenant)
+	if err != nil {
+		return 0, err
+	}
+
+	// comments removed earlier keep their removal
+	query = `UPDATE comments SET body='', user_uid=$1, is_deleted=true, modified_at=$2,
+		removed_at=COALESCE(removed_at, $2), removed_by=CASE WHEN is_deleted THEN removed_by ELSE $3 END,
+		removal_reason=CASE WHEN is_deleted THEN removal_reason ELSE $4 END
+		WHERE user_uid=$5 AND tenant=$6`
+	nRows, err := execContext(ctx, tx, "eraseUser.redact", query, erasedUserUID.String(), time.Now(), removedBySystem, reasonUserErased, userUID.String(), tenant)
+	if err != nil {
+		return 0, err
+	}
+
+	query = "DELETE FROM comment_drafts WHERE user_uid=$1 AND tenant=$2"
+	_, err = execContext(ctx, tx, "eraseUser.deleteDrafts", query, userUID.String(), tenant)
+	if err != nil {
+		return 0, err
+	}
+
+	return nRows, fromPostgres(tx.Commit())
+}
+
+// execBatches runs query until it affects no rows and returns total number of affected rows.
+// Every run is a separate statement, so locks are held only for one batch.
+func (db *db) execBatches(ctx context.Context, name, query string, args ...interface{}) (int64, error) {
+	var total int64
+	for {
+		nRows, err := execContext(ctx, db, name, query, args...)
+		if err != nil {
+			return total, err
+		}
+
+		if nRows == 0 {
+			return total, nil
+		}
+
+		total += nRows
+	}
+}
+
+func (db *db) deleteForTarget(ctx context.Context, target Target, batchSize int32) (int64, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := "DELETE FROM comments WHERE uid IN (SELECT uid FROM comments WHERE tenant=$1 AND resource_type=$2 AND resource_id=$3 LIMIT $4)"
+	return db.execBatches(ctx, "deleteForTarget", query, tenant, target.Type, target.ID, batchSize)
+}
+
+func (db *db) removeContentForTarget(ctx context.Context, target Target, batchSize int32) (int64, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `UPDATE comments SET is_deleted=true, modified_at=$1, removed_at=$1, removed_by=$2, removal_reason=$3, removal_notes=''
+		WHERE uid IN (SELECT uid FROM comments WHERE tenant=$4 AND resource_type=$5 AND resource_id=$6 AND is_deleted=false LIMIT $7)`
+	return db.execBatches(ctx, "removeContentForTarget", query, time.Now(), removedBySystem, reasonResourceRemoved, tenant, target.Type, target.ID, batchSize)
+}
+
+// getPending returns comments waiting for approval, oldest first
+func (db *db) getPending(ctx context.Context, pageSize, pageNumber int32) ([]*Comment, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + commentColumns + " FROM comments WHERE tenant=$1 AND is_pending=true ORDER BY created_at LIMIT $2 OFFSET $3"
+	return queryComments(ctx, db.reader(ctx), "getPending", query, tenant, pageSize, pageNumber*pageSize)
+}
+
+// getRemoved returns removed comments, most recently removed first, empty reason matches every reason
+func (db *db) getRemoved(ctx context.Context, reason string, pageSize, pageNumber int32) ([]*Comment, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + commentColumns + " FROM comments WHERE tenant=$1 AND is_deleted=true AND ($2='' OR removal_reason=$2) ORDER BY removed_at DESC NULLS LAST LIMIT $3 OFFSET $4"
+	return queryComments(ctx, db.reader(ctx), "getRemoved", query, tenant, reason, pageSize, pageNumber*pageSize)
+}
+
+// approve makes pending comment visible and returns it
+func (db *db) approve(ctx context.Context, uid uuid.UUID) (*Comment, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "UPDATE comments SET is_pending=false WHERE uid=$1 AND tenant=$2 AND is_pending=true RETURNING " + commentColumns
+	comments, err := queryComments(ctx, db, "approve", query, uid.String(), tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, errNotFound
+	}
+
+	return comments[0], nil
+}
+
+// claimGuest makes user author of guest comments left with email of emailHash
+func (db *db) claimGuest(ctx context.Context, emailHash string, userUID uuid.UUID) (int64, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := "UPDATE comments SET user_uid=$1, guest_name='', guest_email_hash=NULL WHERE tenant=$2 AND guest_email_hash=$3"
+	return execContext(ctx, db, "claimGuest", query, userUID.String(), tenant, emailHash)
+}
+
+func scanDraft(row scanner) (*Draft, error) {
+	draft := new(Draft)
+	var userUID, parentUID string
+	err := row.Scan(&userUID, &draft.Target.Type, &draft.Target.ID, &parentUID, &draft.Body, &draft.CreatedAt, &draft.ModifiedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	draft.UserUID, err = uuid.Parse(userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	draft.ParentUID, err = uuid.Parse(parentUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// queryDrafts runs query selecting draftColumns and scans all returned drafts
+func queryDrafts(ctx context.Context, q queryer, name, query string, args ...interface{}) ([]*Draft, error) {
+	result := make([]*Draft, 0)
+	err := queryRows(ctx, q, name, func(rows *sql.Rows) error {
+		draft, err := scanDraft(rows)
+		if err != nil {
+			return err
+		}
+
+		result = append(result, draft)
+		return nil
+	}, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// saveDraft creates or replaces draft of user, drafts of user not saved since notBefore are deleted as expired
+func (db *db) saveDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID, body string, notBefore time.Time) (*Draft, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "DELETE FROM comment_drafts WHERE tenant=$1 AND user_uid=$2 AND modified_at<$3"
+	_, err = execContext(ctx, db, "saveDraft.deleteExpired", query, tenant, userUID.String(), notBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `INSERT INTO comment_drafts (tenant, user_uid, resource_type, resource_id, parent_uid, body, created_at, modified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		ON CONFLICT (tenant, user_uid, resource_type, resource_id, parent_uid) DO UPDATE SET body=EXCLUDED.body, modified_at=EXCLUDED.modified_at
+		RETURNING ` + draftColumns
+	drafts, err := queryDrafts(ctx, db, "saveDraft.upsert", query, tenant, userUID.String(), target.Type, target.ID, parentUID.String(), body, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(drafts) == 0 {
+		return nil, errNotCreated
+	}
+
+	return drafts[0], nil
+}
+
+// getDraft returns draft of user saved since notBefore.
+// Drafts are read from primary, since they are usually read right after being saved.
+func (db *db) getDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID, notBefore time.Time) (*Draft, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + draftColumns + " FROM comment_drafts WHERE tenant=$1 AND user_uid=$2 AND resource_type=$3 AND resource_id=$4 AND parent_uid=$5 AND modified_at>=$6"
+	drafts, err := queryDrafts(ctx, db, "getDraft", query, tenant, userUID.String(), target.Type, target.ID, parentUID.String(), notBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(drafts) == 0 {
+		return nil, errDraftNotFound
+	}
+
+	return drafts[0], nil
+}
+
+// getDrafts returns drafts of user saved since notBefore, most recently saved first
+func (db *db) getDrafts(ctx context.Context, userUID uuid.UUID, notBefore time.Time, pageSize, pageNumber int32) ([]*Draft, error) {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + draftColumns + " FROM comment_drafts WHERE tenant=$1 AND user_uid=$2 AND modified_at>=$3 ORDER BY modified_at DESC LIMIT $4 OFFSET $5"
+	return queryDrafts(ctx, db, "getDrafts", query, tenant, userUID.String(), notBefore, pageSize, pageNumber*pageSize)
+}
+
+func (db *db) deleteDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID) error {
+	tenant, err := tenantOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := "DELETE FROM comment_drafts WHERE tenant=$1 AND user_uid=$2 AND resource_type=$3 AND resource_id=$4 AND parent_uid=$5"
+	nRows, err := execContext(ctx, db, "deleteDraft", query, tenant, userUID.String(), target.Type, target.ID, parentUID.String())
+	if err != nil {
+		return err
+	}
+
+	if nRows == 0 {
+		return errDraftNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package comment
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+)
+
+// TestDeleteKeepsTreeConsistent deletes a parent in every delete mode and checks
+// that every surviving reply is still reachable from the top level
+func TestDeleteKeepsTreeConsistent(t *testing.T) {
+	d := openTestDB(t)
+	defer d.close()
+
+	ctx := withTenant(context.Background(), "delete-"+uuid.New().String())
+	for pbMode, mode := range deleteModes {
+		target := postTarget(uuid.New())
+		create := func(body string, parentUID uuid.UUID) *Comment {
+			comment, err := d.create(ctx, target, body, parentUID, uuid.New(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			return comment
+		}
+
+		parent := create("parent", uuid.Nil)
+		reply := create("reply", parent.UID)
+		nested := create("nested reply", reply.UID)
+
+		if _, err := d.delete(ctx, parent.UID, mode, Removal{By: removedByAuthor}); err != nil && err != errHasReplies {
+			t.Fatalf("%v: unexpected error %v", pbMode, err)
+		}
+
+		reachable := make(map[uuid.UUID]bool)
+		var walk func(parentUID uuid.UUID)
+		walk = func(parentUID uuid.UUID) {
+			comments, err := d.getAll(ctx, target, parentUID, 100, 0)
+			if err != nil {
+				t.Fatalf("%v: unexpected error %v", pbMode, err)
+			}
+
+			for _, comment := range comments {
+				reachable[comment.UID] = true
+				walk(comment.UID)
+			}
+		}
+
+		walk(uuid.Nil)
+		for _, comment := range []*Comment{parent, reply, nested} {
+			if _, err := d.getOne(ctx, comment.UID); err == nil && !reachable[comment.UID] {
+				t.Errorf("%v: comment %q survived but is unreachable", pbMode, comment.Body)
+			}
+		}
+
+		d.deleteForTarget(ctx, target, 100)
+	}
+}
+
+func TestEraseUserKeepsGuestComments(t *testing.T) {
+	d := openTestDB(t)
+	defer d.close()
+
+	ctx := withTenant(context.Background(), "erase-"+uuid.New().String())
+	target := postTarget(uuid.New())
+	userUID := uuid.New()
+	own, err := d.create(ctx, target, "own", uuid.Nil, userUID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	guest, err := d.create(ctx, target, "guest", uuid.Nil, uuid.Nil, &Guest{Name: "guest", ChallengeUID: uuid.New()})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err := d.eraseUser(ctx, userUID); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	erased, err := d.getOne(ctx, own.UID)
+	if err != nil || erased.UserUID != erasedUserUID || !erased.IsDeleted {
+		t.Errorf("expected comment of erased user, got %+v, %v", erased, err)
+	}
+
+	if err := d.restoreContent(ctx, own.UID); err != errNotFound {
+		t.Errorf("expected comment of erased user not to be restorable, got %v", err)
+	}
+
+	if err := d.removeContent(ctx, guest.UID, Removal{By: removedByModerator}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := d.restoreContent(ctx, guest.UID); err != nil {
+		t.Errorf("expected guest comment to be restorable, got %v", err)
+	}
+
+	d.deleteForTarget(ctx, target, 100)
+}
@@ -0,0 +1,214 @@
+package comment
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// removedPlaceholder replaces bodies of removed comments in responses to anyone but moderators
+const removedPlaceholder = "[removed]"
+
+const maxRemovalNotesLength = 2000
+
+// reasons of removals done by the service itself
+const (
+	reasonResourceRemoved = "resource_removed"
+	reasonUserErased      = "user_erased"
+)
+
+// removedBodyEvent is the audit log event of a moderator reading original bodies of removed comments
+const removedBodyEvent = "removed_body_access"
+
+var (
+	errBadRemover      = invalidArgument("removedBy", "invalid remover")
+	errUnknownReason   = invalidArgument("reason", "unknown removal reason")
+	errReasonRequired  = invalidArgument("reason", "reason is required unless comment is removed by its author")
+	errNotesTooLong    = invalidArgument("notes", "removal notes are too long")
+	statusNotModerator = status.Error(codes.PermissionDenied, "only moderators may read removed comment bodies")
+)
+
+var removerNames = map[pb.Remover]string{
+	pb.Remover_AUTHOR:    removedByAuthor,
+	pb.Remover_MODERATOR: removedByModerator,
+	pb.Remover_SYSTEM:    removedBySystem,
+}
+
+var removersByName = map[string]pb.Remover{
+	removedByAuthor:    pb.Remover_AUTHOR,
+	removedByModerator: pb.Remover_MODERATOR,
+	removedBySystem:    pb.Remover_SYSTEM,
+}
+
+// systemReasons are always part of removal reason catalogue
+var systemReasons = map[string]string{
+	reasonResourceRemoved: "Commented resource was removed",
+	reasonUserErased:      "Author's data was erased",
+	reasonRemovedAtSource: "Removed before comments were imported",
+}
+
+// defaultReasons is the removal reason catalogue of config which doesn't define one
+var defaultReasons = map[string]string{
+	"spam":      "Spam or advertising",
+	"abuse":     "Harassment or hate speech",
+	"off_topic": "Off topic",
+	"illegal":   "Illegal content",
+	"other":     "Other, see notes",
+}
+
+// reasonCodePattern limits reason codes to short lowercase identifiers
+var reasonCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ModerationConfig describes who moderates comments and why comments may be removed
+type ModerationConfig struct {
+	// Identities of services acting on behalf of moderators, only they may read original bodies of removed comments
+	Identities []string
+	// Reasons maps removal reason codes to their descriptions, nil means the default catalogue.
+	// Reasons of system removals are always added.
+	Reasons map[string]string
+}
+
+// Validate checks that moderation config is consistent
+func (c ModerationConfig) Validate() error {
+	for code, description := range c.Reasons {
+		if !reasonCodePattern.MatchString(code) {
+			return fmt.Errorf("invalid removal reason code %q", code)
+		}
+
+		if description == "" {
+			return fmt.Errorf("removal reason %s has no description", code)
+		}
+	}
+
+	return nil
+}
+
+// reasons returns removal reason catalogue
+func (c ModerationConfig) reasons() map[string]string {
+	configured := c.Reasons
+	if configured == nil {
+		configured = defaultReasons
+	}
+
+	result := make(map[string]string, len(configured)+len(systemReasons))
+	for code, description := range systemReasons {
+		result[code] = description
+	}
+
+	for code, description := range configured {
+		result[code] = description
+	}
+
+	return result
+}
+
+// moderator reports whether caller acts on behalf of moderators
+func (c ModerationConfig) moderator(ctx context.Context) bool {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, moderator := range c.Identities {
+		if moderator == identity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removal validates who removed comment and why, reason must be in the catalogue
+func (c ModerationConfig) removal(removedBy pb.Remover, reason, notes string) (Removal, error) {
+	name, ok := removerNames[removedBy]
+	if !ok && removedBy != pb.Remover_REMOVER_UNSPECIFIED {
+		return Removal{}, errBadRemover
+	}
+
+	if reason == "" && (removedBy == pb.Remover_MODERATOR || removedBy == pb.Remover_SYSTEM) {
+		return Removal{}, errReasonRequired
+	}
+
+	if _, ok := c.reasons()[reason]; reason != "" && !ok {
+		return Removal{}, errUnknownReason
+	}
+
+	if utf8.RuneCountInString(notes) > maxRemovalNotesLength {
+		return Removal{}, errNotesTooLong
+	}
+
+	return Removal{By: name, Reason: reason, Notes: notes}, nil
+}
+
+// removalReasons returns removal reason catalogue sorted by code
+func (c ModerationConfig) removalReasons() []*pb.RemovalReason {
+	reasons := c.reasons()
+	result := make([]*pb.RemovalReason, 0, len(reasons))
+	for code, description := range reasons {
+		result = append(result, &pb.RemovalReason{Code: code, Description: description})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+	return result
+}
+
+// showRemoved checks that only moderators ask for original bodies of removed comments
+func (s *Server) showRemoved(ctx context.Context, includeRemovedBody bool) (bool, error) {
+	if includeRemovedBody && !s.conf.Moderation.moderator(ctx) {
+		return false, statusNotModerator
+	}
+
+	return includeRemovedBody, nil
+}
+
+type auditEntry struct {
+	Time        string   `json:"time"`
+	Event       string   `json:"event"`
+	Method      string   `json:"method"`
+	Identity    string   `json:"identity"`
+	Tenant      string   `json:"tenant"`
+	RequestID   string   `json:"request_id"`
+	CommentUIDs []string `json:"comment_uids"`
+}
+
+// auditRemovedBodies writes entry to request log if original bodies of removed comments were sent to a moderator
+func auditRemovedBodies(ctx context.Context, method string, comments ...*Comment) {
+	var uids []string
+	for _, comment := range comments {
+		if comment.IsDeleted && comment.Body != "" {
+			uids = append(uids, comment.UID.String())
+		}
+	}
+
+	if len(uids) == 0 {
+		return
+	}
+
+	identity, _ := IdentityFromContext(ctx)
+	entry := auditEntry{
+		Time:        time.Now().UTC().Format(time.RFC3339Nano),
+		Event:       removedBodyEvent,
+		Method:      method,
+		Identity:    identity,
+		Tenant:      TenantFromContext(ctx),
+		RequestID:   RequestIDFromContext(ctx),
+		CommentUIDs: uids,
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("marshaling audit log entry failed: %v", err)
+		return
+	}
+
+	requestLog.Println(string(line))
+}
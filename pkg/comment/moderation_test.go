@@ -0,0 +1,171 @@
+package comment
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRemoval(t *testing.T) {
+	conf := ModerationConfig{Reasons: map[string]string{"spam": "Spam"}}
+	longNotes := strings.Repeat("x", maxRemovalNotesLength+1)
+	tests := []struct {
+		name      string
+		removedBy pb.Remover
+		reason    string
+		notes     string
+		removal   Removal
+		err       error
+	}{
+		{"unspecified", pb.Remover_REMOVER_UNSPECIFIED, "", "", Removal{}, nil},
+		{"author", pb.Remover_AUTHOR, "", "typo", Removal{By: removedByAuthor, Notes: "typo"}, nil},
+		{"moderator", pb.Remover_MODERATOR, "spam", "", Removal{By: removedByModerator, Reason: "spam"}, nil},
+		{"system", pb.Remover_SYSTEM, reasonUserErased, "", Removal{By: removedBySystem, Reason: reasonUserErased}, nil},
+		{"unknown remover", pb.Remover(42), "", "", Removal{}, errBadRemover},
+		{"moderator without reason", pb.Remover_MODERATOR, "", "", Removal{}, errReasonRequired},
+		{"default reason", pb.Remover_MODERATOR, "abuse", "", Removal{}, errUnknownReason},
+		{"long notes", pb.Remover_AUTHOR, "", longNotes, Removal{}, errNotesTooLong},
+	}
+
+	for _, test := range tests {
+		removal, err := conf.removal(test.removedBy, test.reason, test.notes)
+		if removal != test.removal || err != test.err {
+			t.Errorf("%s: expected %v, %v, got %v, %v", test.name, test.removal, test.err, removal, err)
+		}
+	}
+}
+
+func TestRemovalReasons(t *testing.T) {
+	reasons := (ModerationConfig{}).removalReasons()
+	if len(reasons) != len(defaultReasons)+len(systemReasons) {
+		t.Errorf("expected default and system reasons, got %v", reasons)
+	}
+
+	for i := 1; i < len(reasons); i++ {
+		if reasons[i-1].Code >= reasons[i].Code {
+			t.Errorf("expected reasons sorted by code, got %v", reasons)
+		}
+	}
+
+	if err := (ModerationConfig{Reasons: map[string]string{"Spam!": "Spam"}}).Validate(); err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+
+	if err := (ModerationConfig{Reasons: map[string]string{"spam": ""}}).Validate(); err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}
+
+func TestListRemovedComments(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	res, err := s.ListRemovedComments(context.Background(), &pb.ListRemovedCommentsRequest{Reason: "spam"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(res.Comments) != 1 {
+		t.Fatalf("expected 1 removed comment, got %v", res.Comments)
+	}
+
+	removed := res.Comments[0]
+	if removed.Body != removedPlaceholder || removed.RemovedBy != pb.Remover_MODERATOR || removed.RemovalReason != "spam" ||
+		removed.RemovalNotes != "links to a shop" || removed.RemovedAt == nil {
+		t.Errorf("unexpected removed comment %v", removed)
+	}
+
+	_, err = s.ListRemovedComments(context.Background(), &pb.ListRemovedCommentsRequest{IncludeRemovedBody: true})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestRedactRemovedComments(t *testing.T) {
+	s := &Server{db: &mockdb{}, conf: Config{Moderation: ModerationConfig{Identities: []string{"moderation"}}}}
+	req := &pb.ListCommentsRequest{PostUid: nilUIDString}
+	res, err := s.ListComments(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	removed := res.Comments[2]
+	if removed.Body != removedPlaceholder || removed.RemovedBy != pb.Remover_MODERATOR || removed.RemovalReason != "spam" {
+		t.Errorf("expected redacted comment, got %v", removed)
+	}
+
+	if res.Comments[0].Body != "first comment body" || res.Comments[0].RemovedBy != pb.Remover_REMOVER_UNSPECIFIED {
+		t.Errorf("expected visible comment to be intact, got %v", res.Comments[0])
+	}
+
+	req.IncludeRemovedBody = true
+	gateway := context.WithValue(context.Background(), identityKey{}, "gateway")
+	if _, err := s.ListComments(gateway, req); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+
+	_, err = s.GetComment(gateway, &pb.GetCommentRequest{Uid: nilUIDString, IncludeRemovedBody: true})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestModeratorReadsRemovedBody(t *testing.T) {
+	buf, restore := captureRequestLog()
+	defer restore()
+
+	s := &Server{db: &mockdb{}, conf: Config{Moderation: ModerationConfig{Identities: []string{"moderation"}}}}
+	ctx := withTenant(context.WithValue(context.Background(), identityKey{}, "moderation"), "blog")
+	res, err := s.ListComments(ctx, &pb.ListCommentsRequest{PostUid: nilUIDString, IncludeRemovedBody: true})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	removed := res.Comments[2]
+	if removed.Body != "third comment body" || removed.RemovedBy != pb.Remover_MODERATOR {
+		t.Errorf("expected original body, got %v", removed)
+	}
+
+	var entry auditEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid audit log entry %q: %v", buf.String(), err)
+	}
+
+	if entry.Event != removedBodyEvent || entry.Identity != "moderation" || entry.Tenant != "blog" {
+		t.Errorf("unexpected audit log entry %+v", entry)
+	}
+
+	if len(entry.CommentUIDs) != 1 || entry.CommentUIDs[0] != removed.Uid {
+		t.Errorf("expected audit of %s, got %v", removed.Uid, entry.CommentUIDs)
+	}
+
+	buf.Reset()
+	if _, err := s.GetComment(ctx, &pb.GetCommentRequest{Uid: nilUIDString, IncludeRemovedBody: true}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no audit of visible comment, got %q", buf.String())
+	}
+}
+
+func TestRemoveContentValidation(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.RemoveContentRequest{Uid: nilUIDString, RemovedBy: pb.Remover_AUTHOR, Notes: "changed my mind"}
+	if _, err := s.RemoveContent(context.Background(), req); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	req.RemovedBy = pb.Remover(42)
+	if _, err := s.RemoveContent(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	deleteReq := &pb.DeleteCommentRequest{Uid: nilUIDString, RemovedBy: pb.Remover_MODERATOR, Reason: "unknown"}
+	if _, err := s.DeleteComment(context.Background(), deleteReq); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
@@ -7,6 +7,8 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+import _ "google.golang.org/genproto/googleapis/api/annotations"
+import field_mask "google.golang.org/genproto/protobuf/field_mask"
 
 import (
 	context "golang.org/x/net/context"
@@ -24,11 +26,74 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// Remover tells who removed content of a comment
+type Remover int32
+
+const (
+	Remover_REMOVER_UNSPECIFIED Remover = 0
+	Remover_AUTHOR              Remover = 1
+	Remover_MODERATOR           Remover = 2
+	// SYSTEM removals are done by automated processes, e.g. when commented resource or user is deleted
+	Remover_SYSTEM Remover = 3
+)
+
+var Remover_name = map[int32]string{
+	0: "REMOVER_UNSPECIFIED",
+	1: "AUTHOR",
+	2: "MODERATOR",
+	3: "SYSTEM",
+}
+var Remover_value = map[string]int32{
+	"REMOVER_UNSPECIFIED": 0,
+	"AUTHOR":              1,
+	"MODERATOR":           2,
+	"SYSTEM":              3,
+}
+
+func (x Remover) String() string {
+	return proto.EnumName(Remover_name, int32(x))
+}
+func (Remover) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{0}
+}
+
+// DeleteMode describes what to do with replies of deleted comment, no mode leaves replies without their parent.
+// REJECT_IF_HAS_REPLIES is the default.
+type DeleteMode int32
+
+const (
+	DeleteMode_REJECT_IF_HAS_REPLIES DeleteMode = 0
+	DeleteMode_CASCADE               DeleteMode = 1
+	DeleteMode_TOMBSTONE             DeleteMode = 2
+)
+
+var DeleteMode_name = map[int32]string{
+	0: "REJECT_IF_HAS_REPLIES",
+	1: "CASCADE",
+	2: "TOMBSTONE",
+}
+var DeleteMode_value = map[string]int32{
+	"REJECT_IF_HAS_REPLIES": 0,
+	"CASCADE":               1,
+	"TOMBSTONE":             2,
+}
+
+func (x DeleteMode) String() string {
+	return proto.EnumName(DeleteMode_name, int32(x))
+}
+func (DeleteMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{1}
+}
+
 type ListCommentsRequest struct {
-	PostUid              string   `protobuf:"bytes,1,opt,name=postUid,proto3" json:"postUid,omitempty"`
-	CommentUid           string   `protobuf:"bytes,2,opt,name=commentUid,proto3" json:"commentUid,omitempty"`
-	PageSize             int32    `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
-	PageNumber           int32    `protobuf:"varint,4,opt,name=pageNumber,proto3" json:"pageNumber,omitempty"`
+	PostUid      string `protobuf:"bytes,1,opt,name=postUid,proto3" json:"postUid,omitempty"`
+	CommentUid   string `protobuf:"bytes,2,opt,name=commentUid,proto3" json:"commentUid,omitempty"`
+	PageSize     int32  `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageNumber   int32  `protobuf:"varint,4,opt,name=pageNumber,proto3" json:"pageNumber,omitempty"`
+	ResourceType string `protobuf:"bytes,5,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId   string `protobuf:"bytes,6,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	// includeRemovedBody returns original bodies of removed comments, only moderators may set it
+	IncludeRemovedBody   bool     `protobuf:"varint,7,opt,name=includeRemovedBody,proto3" json:"includeRemovedBody,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -38,7 +103,7 @@ func (m *ListCommentsRequest) Reset()         { *m = ListCommentsRequest{} }
 func (m *ListCommentsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListCommentsRequest) ProtoMessage()    {}
 func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{0}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{0}
 }
 func (m *ListCommentsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListCommentsRequest.Unmarshal(m, b)
@@ -86,6 +151,27 @@ func (m *ListCommentsRequest) GetPageNumber() int32 {
 	return 0
 }
 
+func (m *ListCommentsRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *ListCommentsRequest) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *ListCommentsRequest) GetIncludeRemovedBody() bool {
+	if m != nil {
+		return m.IncludeRemovedBody
+	}
+	return false
+}
+
 type ListCommentsResponse struct {
 	Comments             []*SingleComment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
 	PageSize             int32            `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
@@ -99,7 +185,7 @@ func (m *ListCommentsResponse) Reset()         { *m = ListCommentsResponse{} }
 func (m *ListCommentsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListCommentsResponse) ProtoMessage()    {}
 func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{1}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{1}
 }
 func (m *ListCommentsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListCommentsResponse.Unmarshal(m, b)
@@ -141,14 +227,28 @@ func (m *ListCommentsResponse) GetPageNumber() int32 {
 }
 
 type SingleComment struct {
-	Uid                  string               `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	UserUid              string               `protobuf:"bytes,2,opt,name=userUid,proto3" json:"userUid,omitempty"`
-	PostUid              string               `protobuf:"bytes,3,opt,name=postUid,proto3" json:"postUid,omitempty"`
-	Body                 string               `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
-	ParentUid            string               `protobuf:"bytes,5,opt,name=parentUid,proto3" json:"parentUid,omitempty"`
-	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
-	ModifiedAt           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=modifiedAt,proto3" json:"modifiedAt,omitempty"`
-	IsDeleted            bool                 `protobuf:"varint,8,opt,name=isDeleted,proto3" json:"isDeleted,omitempty"`
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// userUid of comments whose author was erased is ffffffff-ffff-ffff-ffff-ffffffffffff
+	UserUid      string               `protobuf:"bytes,2,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	PostUid      string               `protobuf:"bytes,3,opt,name=postUid,proto3" json:"postUid,omitempty"`
+	Body         string               `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	ParentUid    string               `protobuf:"bytes,5,opt,name=parentUid,proto3" json:"parentUid,omitempty"`
+	CreatedAt    *timestamp.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ModifiedAt   *timestamp.Timestamp `protobuf:"bytes,7,opt,name=modifiedAt,proto3" json:"modifiedAt,omitempty"`
+	IsDeleted    bool                 `protobuf:"varint,8,opt,name=isDeleted,proto3" json:"isDeleted,omitempty"`
+	ResourceType string               `protobuf:"bytes,9,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId   string               `protobuf:"bytes,10,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	// guestName is the display name of guest author, userUid of guest comments is nil UUID
+	GuestName string `protobuf:"bytes,11,opt,name=guestName,proto3" json:"guestName,omitempty"`
+	// isPending comments are hidden until approved by moderator
+	IsPending bool `protobuf:"varint,12,opt,name=isPending,proto3" json:"isPending,omitempty"`
+	// removedBy, removalReason, removalNotes and removedAt describe removal of deleted comments,
+	// their body is replaced with a placeholder unless a moderator asked for the original one
+	RemovedBy Remover `protobuf:"varint,13,opt,name=removedBy,proto3,enum=comment.Remover" json:"removedBy,omitempty"`
+	// removalReason is a code from the catalogue returned by ListRemovalReasons
+	RemovalReason        string               `protobuf:"bytes,14,opt,name=removalReason,proto3" json:"removalReason,omitempty"`
+	RemovalNotes         string               `protobuf:"bytes,15,opt,name=removalNotes,proto3" json:"removalNotes,omitempty"`
+	RemovedAt            *timestamp.Timestamp `protobuf:"bytes,16,opt,name=removedAt,proto3" json:"removedAt,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -158,7 +258,7 @@ func (m *SingleComment) Reset()         { *m = SingleComment{} }
 func (m *SingleComment) String() string { return proto.CompactTextString(m) }
 func (*SingleComment) ProtoMessage()    {}
 func (*SingleComment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{2}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{2}
 }
 func (m *SingleComment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SingleComment.Unmarshal(m, b)
@@ -234,8 +334,66 @@ func (m *SingleComment) GetIsDeleted() bool {
 	return false
 }
 
+func (m *SingleComment) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *SingleComment) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *SingleComment) GetGuestName() string {
+	if m != nil {
+		return m.GuestName
+	}
+	return ""
+}
+
+func (m *SingleComment) GetIsPending() bool {
+	if m != nil {
+		return m.IsPending
+	}
+	return false
+}
+
+func (m *SingleComment) GetRemovedBy() Remover {
+	if m != nil {
+		return m.RemovedBy
+	}
+	return Remover_REMOVER_UNSPECIFIED
+}
+
+func (m *SingleComment) GetRemovalReason() string {
+	if m != nil {
+		return m.RemovalReason
+	}
+	return ""
+}
+
+func (m *SingleComment) GetRemovalNotes() string {
+	if m != nil {
+		return m.RemovalNotes
+	}
+	return ""
+}
+
+func (m *SingleComment) GetRemovedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RemovedAt
+	}
+	return nil
+}
+
 type GetCommentRequest struct {
-	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// includeRemovedBody returns original body of removed comment, only moderators may set it
+	IncludeRemovedBody   bool     `protobuf:"varint,2,opt,name=includeRemovedBody,proto3" json:"includeRemovedBody,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -245,7 +403,7 @@ func (m *GetCommentRequest) Reset()         { *m = GetCommentRequest{} }
 func (m *GetCommentRequest) String() string { return proto.CompactTextString(m) }
 func (*GetCommentRequest) ProtoMessage()    {}
 func (*GetCommentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{3}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{3}
 }
 func (m *GetCommentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetCommentRequest.Unmarshal(m, b)
@@ -272,11 +430,22 @@ func (m *GetCommentRequest) GetUid() string {
 	return ""
 }
 
+func (m *GetCommentRequest) GetIncludeRemovedBody() bool {
+	if m != nil {
+		return m.IncludeRemovedBody
+	}
+	return false
+}
+
+// CreateCommentRequest creates comment of user or, if guest is set, of guest without an account.
 type CreateCommentRequest struct {
 	PostUid              string   `protobuf:"bytes,1,opt,name=postUid,proto3" json:"postUid,omitempty"`
 	Body                 string   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 	ParentUid            string   `protobuf:"bytes,3,opt,name=parentUid,proto3" json:"parentUid,omitempty"`
 	UserUid              string   `protobuf:"bytes,4,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	ResourceType         string   `protobuf:"bytes,5,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId           string   `protobuf:"bytes,6,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	Guest                *Guest   `protobuf:"bytes,7,opt,name=guest,proto3" json:"guest,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -286,7 +455,7 @@ func (m *CreateCommentRequest) Reset()         { *m = CreateCommentRequest{} }
 func (m *CreateCommentRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateCommentRequest) ProtoMessage()    {}
 func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{4}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{4}
 }
 func (m *CreateCommentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateCommentRequest.Unmarshal(m, b)
@@ -334,19 +503,110 @@ func (m *CreateCommentRequest) GetUserUid() string {
 	return ""
 }
 
-type UpdateCommentRequest struct {
-	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Body                 string   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+func (m *CreateCommentRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *CreateCommentRequest) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *CreateCommentRequest) GetGuest() *Guest {
+	if m != nil {
+		return m.Guest
+	}
+	return nil
+}
+
+// Guest describes author without an account, who proves to be a person by solving a challenge.
+type Guest struct {
+	DisplayName string `protobuf:"bytes,1,opt,name=displayName,proto3" json:"displayName,omitempty"`
+	// email is optional, only its hash is stored so guest can claim comments after registering
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// challengeToken is the token of challenge returned by GetChallenge, every challenge is used once
+	ChallengeToken string `protobuf:"bytes,3,opt,name=challengeToken,proto3" json:"challengeToken,omitempty"`
+	// solution is any string such that SHA-256 of challengeToken followed by solution
+	// starts with challenge difficulty zero bits
+	Solution             string   `protobuf:"bytes,4,opt,name=solution,proto3" json:"solution,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *Guest) Reset()         { *m = Guest{} }
+func (m *Guest) String() string { return proto.CompactTextString(m) }
+func (*Guest) ProtoMessage()    {}
+func (*Guest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{5}
+}
+func (m *Guest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Guest.Unmarshal(m, b)
+}
+func (m *Guest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Guest.Marshal(b, m, deterministic)
+}
+func (dst *Guest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Guest.Merge(dst, src)
+}
+func (m *Guest) XXX_Size() int {
+	return xxx_messageInfo_Guest.Size(m)
+}
+func (m *Guest) XXX_DiscardUnknown() {
+	xxx_messageInfo_Guest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Guest proto.InternalMessageInfo
+
+func (m *Guest) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *Guest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Guest) GetChallengeToken() string {
+	if m != nil {
+		return m.ChallengeToken
+	}
+	return ""
+}
+
+func (m *Guest) GetSolution() string {
+	if m != nil {
+		return m.Solution
+	}
+	return ""
+}
+
+// UpdateCommentRequest changes fields of comment listed in updateMask to values of the same fields of request.
+// Paths name fields of SingleComment, empty mask changes every mutable field.
+type UpdateCommentRequest struct {
+	Uid                  string                `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Body                 string                `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,3,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
 func (m *UpdateCommentRequest) Reset()         { *m = UpdateCommentRequest{} }
 func (m *UpdateCommentRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateCommentRequest) ProtoMessage()    {}
 func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{5}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{6}
 }
 func (m *UpdateCommentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateCommentRequest.Unmarshal(m, b)
@@ -380,38 +640,19 @@ func (m *UpdateCommentRequest) GetBody() string {
 	return ""
 }
 
-type UpdateCommentResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *UpdateCommentResponse) Reset()         { *m = UpdateCommentResponse{} }
-func (m *UpdateCommentResponse) String() string { return proto.CompactTextString(m) }
-func (*UpdateCommentResponse) ProtoMessage()    {}
-func (*UpdateCommentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{6}
-}
-func (m *UpdateCommentResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateCommentResponse.Unmarshal(m, b)
-}
-func (m *UpdateCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateCommentResponse.Marshal(b, m, deterministic)
-}
-func (dst *UpdateCommentResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateCommentResponse.Merge(dst, src)
-}
-func (m *UpdateCommentResponse) XXX_Size() int {
-	return xxx_messageInfo_UpdateCommentResponse.Size(m)
-}
-func (m *UpdateCommentResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateCommentResponse.DiscardUnknown(m)
+func (m *UpdateCommentRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
 }
 
-var xxx_messageInfo_UpdateCommentResponse proto.InternalMessageInfo
-
+// RemoveContentRequest removes content of comment, reason is required unless comment is removed by author
 type RemoveContentRequest struct {
 	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	RemovedBy            Remover  `protobuf:"varint,2,opt,name=removedBy,proto3,enum=comment.Remover" json:"removedBy,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Notes                string   `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -421,7 +662,7 @@ func (m *RemoveContentRequest) Reset()         { *m = RemoveContentRequest{} }
 func (m *RemoveContentRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveContentRequest) ProtoMessage()    {}
 func (*RemoveContentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{7}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{7}
 }
 func (m *RemoveContentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoveContentRequest.Unmarshal(m, b)
@@ -448,6 +689,27 @@ func (m *RemoveContentRequest) GetUid() string {
 	return ""
 }
 
+func (m *RemoveContentRequest) GetRemovedBy() Remover {
+	if m != nil {
+		return m.RemovedBy
+	}
+	return Remover_REMOVER_UNSPECIFIED
+}
+
+func (m *RemoveContentRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RemoveContentRequest) GetNotes() string {
+	if m != nil {
+		return m.Notes
+	}
+	return ""
+}
+
 type RemoveContentResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -458,7 +720,7 @@ func (m *RemoveContentResponse) Reset()         { *m = RemoveContentResponse{} }
 func (m *RemoveContentResponse) String() string { return proto.CompactTextString(m) }
 func (*RemoveContentResponse) ProtoMessage()    {}
 func (*RemoveContentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{8}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{8}
 }
 func (m *RemoveContentResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoveContentResponse.Unmarshal(m, b)
@@ -478,18 +740,91 @@ func (m *RemoveContentResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_RemoveContentResponse proto.InternalMessageInfo
 
-type DeleteCommentRequest struct {
+type RestoreContentRequest struct {
 	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *RestoreContentRequest) Reset()         { *m = RestoreContentRequest{} }
+func (m *RestoreContentRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreContentRequest) ProtoMessage()    {}
+func (*RestoreContentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{9}
+}
+func (m *RestoreContentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreContentRequest.Unmarshal(m, b)
+}
+func (m *RestoreContentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreContentRequest.Marshal(b, m, deterministic)
+}
+func (dst *RestoreContentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreContentRequest.Merge(dst, src)
+}
+func (m *RestoreContentRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreContentRequest.Size(m)
+}
+func (m *RestoreContentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreContentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreContentRequest proto.InternalMessageInfo
+
+func (m *RestoreContentRequest) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+type RestoreContentResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreContentResponse) Reset()         { *m = RestoreContentResponse{} }
+func (m *RestoreContentResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreContentResponse) ProtoMessage()    {}
+func (*RestoreContentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{10}
+}
+func (m *RestoreContentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreContentResponse.Unmarshal(m, b)
+}
+func (m *RestoreContentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreContentResponse.Marshal(b, m, deterministic)
+}
+func (dst *RestoreContentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreContentResponse.Merge(dst, src)
+}
+func (m *RestoreContentResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreContentResponse.Size(m)
+}
+func (m *RestoreContentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreContentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreContentResponse proto.InternalMessageInfo
+
+// DeleteCommentRequest deletes comment, removedBy, reason and notes are recorded if comment is tombstoned
+type DeleteCommentRequest struct {
+	Uid                  string     `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Mode                 DeleteMode `protobuf:"varint,2,opt,name=mode,proto3,enum=comment.DeleteMode" json:"mode,omitempty"`
+	RemovedBy            Remover    `protobuf:"varint,3,opt,name=removedBy,proto3,enum=comment.Remover" json:"removedBy,omitempty"`
+	Reason               string     `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Notes                string     `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
 func (m *DeleteCommentRequest) Reset()         { *m = DeleteCommentRequest{} }
 func (m *DeleteCommentRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteCommentRequest) ProtoMessage()    {}
 func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{9}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{11}
 }
 func (m *DeleteCommentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteCommentRequest.Unmarshal(m, b)
@@ -516,7 +851,37 @@ func (m *DeleteCommentRequest) GetUid() string {
 	return ""
 }
 
+func (m *DeleteCommentRequest) GetMode() DeleteMode {
+	if m != nil {
+		return m.Mode
+	}
+	return DeleteMode_REJECT_IF_HAS_REPLIES
+}
+
+func (m *DeleteCommentRequest) GetRemovedBy() Remover {
+	if m != nil {
+		return m.RemovedBy
+	}
+	return Remover_REMOVER_UNSPECIFIED
+}
+
+func (m *DeleteCommentRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *DeleteCommentRequest) GetNotes() string {
+	if m != nil {
+		return m.Notes
+	}
+	return ""
+}
+
 type DeleteCommentResponse struct {
+	DeletedCount         int64    `protobuf:"varint,1,opt,name=deletedCount,proto3" json:"deletedCount,omitempty"`
+	Tombstoned           bool     `protobuf:"varint,2,opt,name=tombstoned,proto3" json:"tombstoned,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -526,7 +891,7 @@ func (m *DeleteCommentResponse) Reset()         { *m = DeleteCommentResponse{} }
 func (m *DeleteCommentResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteCommentResponse) ProtoMessage()    {}
 func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{10}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{12}
 }
 func (m *DeleteCommentResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteCommentResponse.Unmarshal(m, b)
@@ -546,6 +911,20 @@ func (m *DeleteCommentResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeleteCommentResponse proto.InternalMessageInfo
 
+func (m *DeleteCommentResponse) GetDeletedCount() int64 {
+	if m != nil {
+		return m.DeletedCount
+	}
+	return 0
+}
+
+func (m *DeleteCommentResponse) GetTombstoned() bool {
+	if m != nil {
+		return m.Tombstoned
+	}
+	return false
+}
+
 type GetOwnerRequest struct {
 	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -557,7 +936,7 @@ func (m *GetOwnerRequest) Reset()         { *m = GetOwnerRequest{} }
 func (m *GetOwnerRequest) String() string { return proto.CompactTextString(m) }
 func (*GetOwnerRequest) ProtoMessage()    {}
 func (*GetOwnerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{11}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{13}
 }
 func (m *GetOwnerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetOwnerRequest.Unmarshal(m, b)
@@ -595,7 +974,7 @@ func (m *GetOwnerResponse) Reset()         { *m = GetOwnerResponse{} }
 func (m *GetOwnerResponse) String() string { return proto.CompactTextString(m) }
 func (*GetOwnerResponse) ProtoMessage()    {}
 func (*GetOwnerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_comment_58f34ab1f7605aa0, []int{12}
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{14}
 }
 func (m *GetOwnerResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetOwnerResponse.Unmarshal(m, b)
@@ -622,48 +1001,1490 @@ func (m *GetOwnerResponse) GetOwnerUid() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*ListCommentsRequest)(nil), "comment.ListCommentsRequest")
-	proto.RegisterType((*ListCommentsResponse)(nil), "comment.ListCommentsResponse")
-	proto.RegisterType((*SingleComment)(nil), "comment.SingleComment")
-	proto.RegisterType((*GetCommentRequest)(nil), "comment.GetCommentRequest")
-	proto.RegisterType((*CreateCommentRequest)(nil), "comment.CreateCommentRequest")
-	proto.RegisterType((*UpdateCommentRequest)(nil), "comment.UpdateCommentRequest")
-	proto.RegisterType((*UpdateCommentResponse)(nil), "comment.UpdateCommentResponse")
-	proto.RegisterType((*RemoveContentRequest)(nil), "comment.RemoveContentRequest")
-	proto.RegisterType((*RemoveContentResponse)(nil), "comment.RemoveContentResponse")
-	proto.RegisterType((*DeleteCommentRequest)(nil), "comment.DeleteCommentRequest")
-	proto.RegisterType((*DeleteCommentResponse)(nil), "comment.DeleteCommentResponse")
-	proto.RegisterType((*GetOwnerRequest)(nil), "comment.GetOwnerRequest")
-	proto.RegisterType((*GetOwnerResponse)(nil), "comment.GetOwnerResponse")
+type CommentRevision struct {
+	Uid                  string               `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	CommentUid           string               `protobuf:"bytes,2,opt,name=commentUid,proto3" json:"commentUid,omitempty"`
+	Body                 string               `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// CommentClient is the client API for Comment service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CommentClient interface {
-	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
-	GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*SingleComment, error)
-	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*SingleComment, error)
-	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error)
-	RemoveContent(ctx context.Context, in *RemoveContentRequest, opts ...grpc.CallOption) (*RemoveContentResponse, error)
-	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
-	GetOwner(ctx context.Context, in *GetOwnerRequest, opts ...grpc.CallOption) (*GetOwnerResponse, error)
+func (m *CommentRevision) Reset()         { *m = CommentRevision{} }
+func (m *CommentRevision) String() string { return proto.CompactTextString(m) }
+func (*CommentRevision) ProtoMessage()    {}
+func (*CommentRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{15}
 }
-
-type commentClient struct {
-	cc *grpc.ClientConn
+func (m *CommentRevision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommentRevision.Unmarshal(m, b)
 }
-
-func NewCommentClient(cc *grpc.ClientConn) CommentClient {
+func (m *CommentRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommentRevision.Marshal(b, m, deterministic)
+}
+func (dst *CommentRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommentRevision.Merge(dst, src)
+}
+func (m *CommentRevision) XXX_Size() int {
+	return xxx_messageInfo_CommentRevision.Size(m)
+}
+func (m *CommentRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommentRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommentRevision proto.InternalMessageInfo
+
+func (m *CommentRevision) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *CommentRevision) GetCommentUid() string {
+	if m != nil {
+		return m.CommentUid
+	}
+	return ""
+}
+
+func (m *CommentRevision) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *CommentRevision) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+// ExportUserDataRequest exports data of user, bodies of removed comments are exported too with their removal as they belong to user
+type ExportUserDataRequest struct {
+	UserUid              string   `protobuf:"bytes,1,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportUserDataRequest) Reset()         { *m = ExportUserDataRequest{} }
+func (m *ExportUserDataRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportUserDataRequest) ProtoMessage()    {}
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{16}
+}
+func (m *ExportUserDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportUserDataRequest.Unmarshal(m, b)
+}
+func (m *ExportUserDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportUserDataRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExportUserDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportUserDataRequest.Merge(dst, src)
+}
+func (m *ExportUserDataRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportUserDataRequest.Size(m)
+}
+func (m *ExportUserDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportUserDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportUserDataRequest proto.InternalMessageInfo
+
+func (m *ExportUserDataRequest) GetUserUid() string {
+	if m != nil {
+		return m.UserUid
+	}
+	return ""
+}
+
+type UserDataRecord struct {
+	Comment              *SingleComment     `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	Revisions            []*CommentRevision `protobuf:"bytes,2,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UserDataRecord) Reset()         { *m = UserDataRecord{} }
+func (m *UserDataRecord) String() string { return proto.CompactTextString(m) }
+func (*UserDataRecord) ProtoMessage()    {}
+func (*UserDataRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{17}
+}
+func (m *UserDataRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserDataRecord.Unmarshal(m, b)
+}
+func (m *UserDataRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserDataRecord.Marshal(b, m, deterministic)
+}
+func (dst *UserDataRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserDataRecord.Merge(dst, src)
+}
+func (m *UserDataRecord) XXX_Size() int {
+	return xxx_messageInfo_UserDataRecord.Size(m)
+}
+func (m *UserDataRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserDataRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserDataRecord proto.InternalMessageInfo
+
+func (m *UserDataRecord) GetComment() *SingleComment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+func (m *UserDataRecord) GetRevisions() []*CommentRevision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type EraseUserRequest struct {
+	UserUid              string   `protobuf:"bytes,1,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EraseUserRequest) Reset()         { *m = EraseUserRequest{} }
+func (m *EraseUserRequest) String() string { return proto.CompactTextString(m) }
+func (*EraseUserRequest) ProtoMessage()    {}
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{18}
+}
+func (m *EraseUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EraseUserRequest.Unmarshal(m, b)
+}
+func (m *EraseUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EraseUserRequest.Marshal(b, m, deterministic)
+}
+func (dst *EraseUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EraseUserRequest.Merge(dst, src)
+}
+func (m *EraseUserRequest) XXX_Size() int {
+	return xxx_messageInfo_EraseUserRequest.Size(m)
+}
+func (m *EraseUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EraseUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EraseUserRequest proto.InternalMessageInfo
+
+func (m *EraseUserRequest) GetUserUid() string {
+	if m != nil {
+		return m.UserUid
+	}
+	return ""
+}
+
+type EraseUserResponse struct {
+	ErasedCount          int64    `protobuf:"varint,1,opt,name=erasedCount,proto3" json:"erasedCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EraseUserResponse) Reset()         { *m = EraseUserResponse{} }
+func (m *EraseUserResponse) String() string { return proto.CompactTextString(m) }
+func (*EraseUserResponse) ProtoMessage()    {}
+func (*EraseUserResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{19}
+}
+func (m *EraseUserResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EraseUserResponse.Unmarshal(m, b)
+}
+func (m *EraseUserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EraseUserResponse.Marshal(b, m, deterministic)
+}
+func (dst *EraseUserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EraseUserResponse.Merge(dst, src)
+}
+func (m *EraseUserResponse) XXX_Size() int {
+	return xxx_messageInfo_EraseUserResponse.Size(m)
+}
+func (m *EraseUserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EraseUserResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EraseUserResponse proto.InternalMessageInfo
+
+func (m *EraseUserResponse) GetErasedCount() int64 {
+	if m != nil {
+		return m.ErasedCount
+	}
+	return 0
+}
+
+type DeleteCommentsForPostRequest struct {
+	PostUid              string   `protobuf:"bytes,1,opt,name=postUid,proto3" json:"postUid,omitempty"`
+	BatchSize            int32    `protobuf:"varint,2,opt,name=batchSize,proto3" json:"batchSize,omitempty"`
+	ResourceType         string   `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId           string   `protobuf:"bytes,4,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentsForPostRequest) Reset()         { *m = DeleteCommentsForPostRequest{} }
+func (m *DeleteCommentsForPostRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentsForPostRequest) ProtoMessage()    {}
+func (*DeleteCommentsForPostRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{20}
+}
+func (m *DeleteCommentsForPostRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentsForPostRequest.Unmarshal(m, b)
+}
+func (m *DeleteCommentsForPostRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentsForPostRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteCommentsForPostRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentsForPostRequest.Merge(dst, src)
+}
+func (m *DeleteCommentsForPostRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentsForPostRequest.Size(m)
+}
+func (m *DeleteCommentsForPostRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentsForPostRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentsForPostRequest proto.InternalMessageInfo
+
+func (m *DeleteCommentsForPostRequest) GetPostUid() string {
+	if m != nil {
+		return m.PostUid
+	}
+	return ""
+}
+
+func (m *DeleteCommentsForPostRequest) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *DeleteCommentsForPostRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *DeleteCommentsForPostRequest) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+type DeleteCommentsForPostResponse struct {
+	AffectedCount        int64    `protobuf:"varint,1,opt,name=affectedCount,proto3" json:"affectedCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentsForPostResponse) Reset()         { *m = DeleteCommentsForPostResponse{} }
+func (m *DeleteCommentsForPostResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentsForPostResponse) ProtoMessage()    {}
+func (*DeleteCommentsForPostResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{21}
+}
+func (m *DeleteCommentsForPostResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentsForPostResponse.Unmarshal(m, b)
+}
+func (m *DeleteCommentsForPostResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentsForPostResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteCommentsForPostResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentsForPostResponse.Merge(dst, src)
+}
+func (m *DeleteCommentsForPostResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentsForPostResponse.Size(m)
+}
+func (m *DeleteCommentsForPostResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentsForPostResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentsForPostResponse proto.InternalMessageInfo
+
+func (m *DeleteCommentsForPostResponse) GetAffectedCount() int64 {
+	if m != nil {
+		return m.AffectedCount
+	}
+	return 0
+}
+
+type RemoveContentForPostRequest struct {
+	PostUid              string   `protobuf:"bytes,1,opt,name=postUid,proto3" json:"postUid,omitempty"`
+	BatchSize            int32    `protobuf:"varint,2,opt,name=batchSize,proto3" json:"batchSize,omitempty"`
+	ResourceType         string   `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId           string   `protobuf:"bytes,4,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveContentForPostRequest) Reset()         { *m = RemoveContentForPostRequest{} }
+func (m *RemoveContentForPostRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveContentForPostRequest) ProtoMessage()    {}
+func (*RemoveContentForPostRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{22}
+}
+func (m *RemoveContentForPostRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveContentForPostRequest.Unmarshal(m, b)
+}
+func (m *RemoveContentForPostRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveContentForPostRequest.Marshal(b, m, deterministic)
+}
+func (dst *RemoveContentForPostRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveContentForPostRequest.Merge(dst, src)
+}
+func (m *RemoveContentForPostRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveContentForPostRequest.Size(m)
+}
+func (m *RemoveContentForPostRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveContentForPostRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveContentForPostRequest proto.InternalMessageInfo
+
+func (m *RemoveContentForPostRequest) GetPostUid() string {
+	if m != nil {
+		return m.PostUid
+	}
+	return ""
+}
+
+func (m *RemoveContentForPostRequest) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *RemoveContentForPostRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *RemoveContentForPostRequest) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+type RemoveContentForPostResponse struct {
+	AffectedCount        int64    `protobuf:"varint,1,opt,name=affectedCount,proto3" json:"affectedCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveContentForPostResponse) Reset()         { *m = RemoveContentForPostResponse{} }
+func (m *RemoveContentForPostResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveContentForPostResponse) ProtoMessage()    {}
+func (*RemoveContentForPostResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{23}
+}
+func (m *RemoveContentForPostResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveContentForPostResponse.Unmarshal(m, b)
+}
+func (m *RemoveContentForPostResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveContentForPostResponse.Marshal(b, m, deterministic)
+}
+func (dst *RemoveContentForPostResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveContentForPostResponse.Merge(dst, src)
+}
+func (m *RemoveContentForPostResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveContentForPostResponse.Size(m)
+}
+func (m *RemoveContentForPostResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveContentForPostResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveContentForPostResponse proto.InternalMessageInfo
+
+func (m *RemoveContentForPostResponse) GetAffectedCount() int64 {
+	if m != nil {
+		return m.AffectedCount
+	}
+	return 0
+}
+
+type GetChallengeRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetChallengeRequest) Reset()         { *m = GetChallengeRequest{} }
+func (m *GetChallengeRequest) String() string { return proto.CompactTextString(m) }
+func (*GetChallengeRequest) ProtoMessage()    {}
+func (*GetChallengeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{24}
+}
+func (m *GetChallengeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetChallengeRequest.Unmarshal(m, b)
+}
+func (m *GetChallengeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetChallengeRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetChallengeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetChallengeRequest.Merge(dst, src)
+}
+func (m *GetChallengeRequest) XXX_Size() int {
+	return xxx_messageInfo_GetChallengeRequest.Size(m)
+}
+func (m *GetChallengeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetChallengeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetChallengeRequest proto.InternalMessageInfo
+
+// Challenge is signed by server and must be solved before it expires
+type Challenge struct {
+	Token                string               `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Difficulty           int32                `protobuf:"varint,2,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Challenge) Reset()         { *m = Challenge{} }
+func (m *Challenge) String() string { return proto.CompactTextString(m) }
+func (*Challenge) ProtoMessage()    {}
+func (*Challenge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{25}
+}
+func (m *Challenge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Challenge.Unmarshal(m, b)
+}
+func (m *Challenge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Challenge.Marshal(b, m, deterministic)
+}
+func (dst *Challenge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Challenge.Merge(dst, src)
+}
+func (m *Challenge) XXX_Size() int {
+	return xxx_messageInfo_Challenge.Size(m)
+}
+func (m *Challenge) XXX_DiscardUnknown() {
+	xxx_messageInfo_Challenge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Challenge proto.InternalMessageInfo
+
+func (m *Challenge) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *Challenge) GetDifficulty() int32 {
+	if m != nil {
+		return m.Difficulty
+	}
+	return 0
+}
+
+func (m *Challenge) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+type ListPendingCommentsRequest struct {
+	PageSize   int32 `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageNumber int32 `protobuf:"varint,2,opt,name=pageNumber,proto3" json:"pageNumber,omitempty"`
+	// includeRemovedBody returns original bodies of removed comments, only moderators may set it
+	IncludeRemovedBody   bool     `protobuf:"varint,3,opt,name=includeRemovedBody,proto3" json:"includeRemovedBody,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPendingCommentsRequest) Reset()         { *m = ListPendingCommentsRequest{} }
+func (m *ListPendingCommentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPendingCommentsRequest) ProtoMessage()    {}
+func (*ListPendingCommentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{26}
+}
+func (m *ListPendingCommentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPendingCommentsRequest.Unmarshal(m, b)
+}
+func (m *ListPendingCommentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPendingCommentsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListPendingCommentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingCommentsRequest.Merge(dst, src)
+}
+func (m *ListPendingCommentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPendingCommentsRequest.Size(m)
+}
+func (m *ListPendingCommentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingCommentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingCommentsRequest proto.InternalMessageInfo
+
+func (m *ListPendingCommentsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListPendingCommentsRequest) GetPageNumber() int32 {
+	if m != nil {
+		return m.PageNumber
+	}
+	return 0
+}
+
+func (m *ListPendingCommentsRequest) GetIncludeRemovedBody() bool {
+	if m != nil {
+		return m.IncludeRemovedBody
+	}
+	return false
+}
+
+// ListRemovedCommentsRequest lists removed comments, most recently removed first, reason filters them by reason code
+type ListRemovedCommentsRequest struct {
+	PageSize   int32  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageNumber int32  `protobuf:"varint,2,opt,name=pageNumber,proto3" json:"pageNumber,omitempty"`
+	Reason     string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// includeRemovedBody returns original bodies of removed comments, only moderators may set it
+	IncludeRemovedBody   bool     `protobuf:"varint,4,opt,name=includeRemovedBody,proto3" json:"includeRemovedBody,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRemovedCommentsRequest) Reset()         { *m = ListRemovedCommentsRequest{} }
+func (m *ListRemovedCommentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRemovedCommentsRequest) ProtoMessage()    {}
+func (*ListRemovedCommentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{27}
+}
+func (m *ListRemovedCommentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRemovedCommentsRequest.Unmarshal(m, b)
+}
+func (m *ListRemovedCommentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRemovedCommentsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListRemovedCommentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRemovedCommentsRequest.Merge(dst, src)
+}
+func (m *ListRemovedCommentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRemovedCommentsRequest.Size(m)
+}
+func (m *ListRemovedCommentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRemovedCommentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRemovedCommentsRequest proto.InternalMessageInfo
+
+func (m *ListRemovedCommentsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListRemovedCommentsRequest) GetPageNumber() int32 {
+	if m != nil {
+		return m.PageNumber
+	}
+	return 0
+}
+
+func (m *ListRemovedCommentsRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ListRemovedCommentsRequest) GetIncludeRemovedBody() bool {
+	if m != nil {
+		return m.IncludeRemovedBody
+	}
+	return false
+}
+
+type ListRemovalReasonsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRemovalReasonsRequest) Reset()         { *m = ListRemovalReasonsRequest{} }
+func (m *ListRemovalReasonsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRemovalReasonsRequest) ProtoMessage()    {}
+func (*ListRemovalReasonsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{28}
+}
+func (m *ListRemovalReasonsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRemovalReasonsRequest.Unmarshal(m, b)
+}
+func (m *ListRemovalReasonsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRemovalReasonsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListRemovalReasonsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRemovalReasonsRequest.Merge(dst, src)
+}
+func (m *ListRemovalReasonsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRemovalReasonsRequest.Size(m)
+}
+func (m *ListRemovalReasonsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRemovalReasonsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRemovalReasonsRequest proto.InternalMessageInfo
+
+type RemovalReason struct {
+	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemovalReason) Reset()         { *m = RemovalReason{} }
+func (m *RemovalReason) String() string { return proto.CompactTextString(m) }
+func (*RemovalReason) ProtoMessage()    {}
+func (*RemovalReason) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{29}
+}
+func (m *RemovalReason) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemovalReason.Unmarshal(m, b)
+}
+func (m *RemovalReason) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemovalReason.Marshal(b, m, deterministic)
+}
+func (dst *RemovalReason) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemovalReason.Merge(dst, src)
+}
+func (m *RemovalReason) XXX_Size() int {
+	return xxx_messageInfo_RemovalReason.Size(m)
+}
+func (m *RemovalReason) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemovalReason.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemovalReason proto.InternalMessageInfo
+
+func (m *RemovalReason) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *RemovalReason) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type ListRemovalReasonsResponse struct {
+	Reasons              []*RemovalReason `protobuf:"bytes,1,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListRemovalReasonsResponse) Reset()         { *m = ListRemovalReasonsResponse{} }
+func (m *ListRemovalReasonsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRemovalReasonsResponse) ProtoMessage()    {}
+func (*ListRemovalReasonsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{30}
+}
+func (m *ListRemovalReasonsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRemovalReasonsResponse.Unmarshal(m, b)
+}
+func (m *ListRemovalReasonsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRemovalReasonsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListRemovalReasonsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRemovalReasonsResponse.Merge(dst, src)
+}
+func (m *ListRemovalReasonsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRemovalReasonsResponse.Size(m)
+}
+func (m *ListRemovalReasonsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRemovalReasonsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRemovalReasonsResponse proto.InternalMessageInfo
+
+func (m *ListRemovalReasonsResponse) GetReasons() []*RemovalReason {
+	if m != nil {
+		return m.Reasons
+	}
+	return nil
+}
+
+type ApproveCommentRequest struct {
+	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveCommentRequest) Reset()         { *m = ApproveCommentRequest{} }
+func (m *ApproveCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*ApproveCommentRequest) ProtoMessage()    {}
+func (*ApproveCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{31}
+}
+func (m *ApproveCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveCommentRequest.Unmarshal(m, b)
+}
+func (m *ApproveCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveCommentRequest.Marshal(b, m, deterministic)
+}
+func (dst *ApproveCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveCommentRequest.Merge(dst, src)
+}
+func (m *ApproveCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_ApproveCommentRequest.Size(m)
+}
+func (m *ApproveCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveCommentRequest proto.InternalMessageInfo
+
+func (m *ApproveCommentRequest) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+// ClaimGuestCommentsRequest makes user author of guest comments left with email.
+// Caller is responsible for verifying that email belongs to user.
+type ClaimGuestCommentsRequest struct {
+	UserUid              string   `protobuf:"bytes,1,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	Email                string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClaimGuestCommentsRequest) Reset()         { *m = ClaimGuestCommentsRequest{} }
+func (m *ClaimGuestCommentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ClaimGuestCommentsRequest) ProtoMessage()    {}
+func (*ClaimGuestCommentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{32}
+}
+func (m *ClaimGuestCommentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClaimGuestCommentsRequest.Unmarshal(m, b)
+}
+func (m *ClaimGuestCommentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClaimGuestCommentsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ClaimGuestCommentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClaimGuestCommentsRequest.Merge(dst, src)
+}
+func (m *ClaimGuestCommentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ClaimGuestCommentsRequest.Size(m)
+}
+func (m *ClaimGuestCommentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClaimGuestCommentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClaimGuestCommentsRequest proto.InternalMessageInfo
+
+func (m *ClaimGuestCommentsRequest) GetUserUid() string {
+	if m != nil {
+		return m.UserUid
+	}
+	return ""
+}
+
+func (m *ClaimGuestCommentsRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+type ClaimGuestCommentsResponse struct {
+	ClaimedCount         int64    `protobuf:"varint,1,opt,name=claimedCount,proto3" json:"claimedCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClaimGuestCommentsResponse) Reset()         { *m = ClaimGuestCommentsResponse{} }
+func (m *ClaimGuestCommentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ClaimGuestCommentsResponse) ProtoMessage()    {}
+func (*ClaimGuestCommentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{33}
+}
+func (m *ClaimGuestCommentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClaimGuestCommentsResponse.Unmarshal(m, b)
+}
+func (m *ClaimGuestCommentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClaimGuestCommentsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ClaimGuestCommentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClaimGuestCommentsResponse.Merge(dst, src)
+}
+func (m *ClaimGuestCommentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ClaimGuestCommentsResponse.Size(m)
+}
+func (m *ClaimGuestCommentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClaimGuestCommentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClaimGuestCommentsResponse proto.InternalMessageInfo
+
+func (m *ClaimGuestCommentsResponse) GetClaimedCount() int64 {
+	if m != nil {
+		return m.ClaimedCount
+	}
+	return 0
+}
+
+type Draft struct {
+	UserUid      string               `protobuf:"bytes,1,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	PostUid      string               `protobuf:"bytes,2,opt,name=postUid,proto3" json:"postUid,omitempty"`
+	ResourceType string               `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId   string               `protobuf:"bytes,4,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	ParentUid    string               `protobuf:"bytes,5,opt,name=parentUid,proto3" json:"parentUid,omitempty"`
+	Body         string               `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt    *timestamp.Timestamp `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ModifiedAt   *timestamp.Timestamp `protobuf:"bytes,8,opt,name=modifiedAt,proto3" json:"modifiedAt,omitempty"`
+	// expiresAt is unset if drafts don't expire, saving draft again postpones expiration
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,9,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Draft) Reset()         { *m = Draft{} }
+func (m *Draft) String() string { return proto.CompactTextString(m) }
+func (*Draft) ProtoMessage()    {}
+func (*Draft) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{34}
+}
+func (m *Draft) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Draft.Unmarshal(m, b)
+}
+func (m *Draft) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Draft.Marshal(b, m, deterministic)
+}
+func (dst *Draft) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Draft.Merge(dst, src)
+}
+func (m *Draft) XXX_Size() int {
+	return xxx_messageInfo_Draft.Size(m)
+}
+func (m *Draft) XXX_DiscardUnknown() {
+	xxx_messageInfo_Draft.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Draft proto.InternalMessageInfo
+
+func (m *Draft) GetUserUid() string {
+	if m != nil {
+		return m.UserUid
+	}
+	return ""
+}
+
+func (m *Draft) GetPostUid() string {
+	if m != nil {
+		return m.PostUid
+	}
+	return ""
+}
+
+func (m *Draft) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *Draft) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *Draft) GetParentUid() string {
+	if m != nil {
+		return m.ParentUid
+	}
+	return ""
+}
+
+func (m *Draft) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *Draft) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Draft) GetModifiedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ModifiedAt
+	}
+	return nil
+}
+
+func (m *Draft) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+type SaveDraftRequest struct {
+	UserUid              string   `protobuf:"bytes,1,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	PostUid              string   `protobuf:"bytes,2,opt,name=postUid,proto3" json:"postUid,omitempty"`
+	ResourceType         string   `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId           string   `protobuf:"bytes,4,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	ParentUid            string   `protobuf:"bytes,5,opt,name=parentUid,proto3" json:"parentUid,omitempty"`
+	Body                 string   `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SaveDraftRequest) Reset()         { *m = SaveDraftRequest{} }
+func (m *SaveDraftRequest) String() string { return proto.CompactTextString(m) }
+func (*SaveDraftRequest) ProtoMessage()    {}
+func (*SaveDraftRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{35}
+}
+func (m *SaveDraftRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveDraftRequest.Unmarshal(m, b)
+}
+func (m *SaveDraftRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveDraftRequest.Marshal(b, m, deterministic)
+}
+func (dst *SaveDraftRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveDraftRequest.Merge(dst, src)
+}
+func (m *SaveDraftRequest) XXX_Size() int {
+	return xxx_messageInfo_SaveDraftRequest.Size(m)
+}
+func (m *SaveDraftRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveDraftRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveDraftRequest proto.InternalMessageInfo
+
+func (m *SaveDraftRequest) GetUserUid() string {
+	if m != nil {
+		return m.UserUid
+	}
+	return ""
+}
+
+func (m *SaveDraftRequest) GetPostUid() string {
+	if m != nil {
+		return m.PostUid
+	}
+	return ""
+}
+
+func (m *SaveDraftRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *SaveDraftRequest) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *SaveDraftRequest) GetParentUid() string {
+	if m != nil {
+		return m.ParentUid
+	}
+	return ""
+}
+
+func (m *SaveDraftRequest) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+type GetDraftRequest struct {
+	UserUid              string   `protobuf:"bytes,1,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	PostUid              string   `protobuf:"bytes,2,opt,name=postUid,proto3" json:"postUid,omitempty"`
+	ResourceType         string   `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId           string   `protobuf:"bytes,4,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	ParentUid            string   `protobuf:"bytes,5,opt,name=parentUid,proto3" json:"parentUid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDraftRequest) Reset()         { *m = GetDraftRequest{} }
+func (m *GetDraftRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDraftRequest) ProtoMessage()    {}
+func (*GetDraftRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{36}
+}
+func (m *GetDraftRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDraftRequest.Unmarshal(m, b)
+}
+func (m *GetDraftRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDraftRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetDraftRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDraftRequest.Merge(dst, src)
+}
+func (m *GetDraftRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDraftRequest.Size(m)
+}
+func (m *GetDraftRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDraftRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDraftRequest proto.InternalMessageInfo
+
+func (m *GetDraftRequest) GetUserUid() string {
+	if m != nil {
+		return m.UserUid
+	}
+	return ""
+}
+
+func (m *GetDraftRequest) GetPostUid() string {
+	if m != nil {
+		return m.PostUid
+	}
+	return ""
+}
+
+func (m *GetDraftRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *GetDraftRequest) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *GetDraftRequest) GetParentUid() string {
+	if m != nil {
+		return m.ParentUid
+	}
+	return ""
+}
+
+type ListDraftsRequest struct {
+	UserUid              string   `protobuf:"bytes,1,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	PageSize             int32    `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageNumber           int32    `protobuf:"varint,3,opt,name=pageNumber,proto3" json:"pageNumber,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDraftsRequest) Reset()         { *m = ListDraftsRequest{} }
+func (m *ListDraftsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDraftsRequest) ProtoMessage()    {}
+func (*ListDraftsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{37}
+}
+func (m *ListDraftsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDraftsRequest.Unmarshal(m, b)
+}
+func (m *ListDraftsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDraftsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListDraftsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDraftsRequest.Merge(dst, src)
+}
+func (m *ListDraftsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDraftsRequest.Size(m)
+}
+func (m *ListDraftsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDraftsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDraftsRequest proto.InternalMessageInfo
+
+func (m *ListDraftsRequest) GetUserUid() string {
+	if m != nil {
+		return m.UserUid
+	}
+	return ""
+}
+
+func (m *ListDraftsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListDraftsRequest) GetPageNumber() int32 {
+	if m != nil {
+		return m.PageNumber
+	}
+	return 0
+}
+
+type ListDraftsResponse struct {
+	Drafts               []*Draft `protobuf:"bytes,1,rep,name=drafts,proto3" json:"drafts,omitempty"`
+	PageSize             int32    `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageNumber           int32    `protobuf:"varint,3,opt,name=pageNumber,proto3" json:"pageNumber,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDraftsResponse) Reset()         { *m = ListDraftsResponse{} }
+func (m *ListDraftsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDraftsResponse) ProtoMessage()    {}
+func (*ListDraftsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{38}
+}
+func (m *ListDraftsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDraftsResponse.Unmarshal(m, b)
+}
+func (m *ListDraftsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDraftsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListDraftsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDraftsResponse.Merge(dst, src)
+}
+func (m *ListDraftsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDraftsResponse.Size(m)
+}
+func (m *ListDraftsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDraftsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDraftsResponse proto.InternalMessageInfo
+
+func (m *ListDraftsResponse) GetDrafts() []*Draft {
+	if m != nil {
+		return m.Drafts
+	}
+	return nil
+}
+
+func (m *ListDraftsResponse) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListDraftsResponse) GetPageNumber() int32 {
+	if m != nil {
+		return m.PageNumber
+	}
+	return 0
+}
+
+type DeleteDraftRequest struct {
+	UserUid              string   `protobuf:"bytes,1,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	PostUid              string   `protobuf:"bytes,2,opt,name=postUid,proto3" json:"postUid,omitempty"`
+	ResourceType         string   `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId           string   `protobuf:"bytes,4,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	ParentUid            string   `protobuf:"bytes,5,opt,name=parentUid,proto3" json:"parentUid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteDraftRequest) Reset()         { *m = DeleteDraftRequest{} }
+func (m *DeleteDraftRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteDraftRequest) ProtoMessage()    {}
+func (*DeleteDraftRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{39}
+}
+func (m *DeleteDraftRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDraftRequest.Unmarshal(m, b)
+}
+func (m *DeleteDraftRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDraftRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteDraftRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDraftRequest.Merge(dst, src)
+}
+func (m *DeleteDraftRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteDraftRequest.Size(m)
+}
+func (m *DeleteDraftRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDraftRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteDraftRequest proto.InternalMessageInfo
+
+func (m *DeleteDraftRequest) GetUserUid() string {
+	if m != nil {
+		return m.UserUid
+	}
+	return ""
+}
+
+func (m *DeleteDraftRequest) GetPostUid() string {
+	if m != nil {
+		return m.PostUid
+	}
+	return ""
+}
+
+func (m *DeleteDraftRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *DeleteDraftRequest) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *DeleteDraftRequest) GetParentUid() string {
+	if m != nil {
+		return m.ParentUid
+	}
+	return ""
+}
+
+type DeleteDraftResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteDraftResponse) Reset()         { *m = DeleteDraftResponse{} }
+func (m *DeleteDraftResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteDraftResponse) ProtoMessage()    {}
+func (*DeleteDraftResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{40}
+}
+func (m *DeleteDraftResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDraftResponse.Unmarshal(m, b)
+}
+func (m *DeleteDraftResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDraftResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteDraftResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDraftResponse.Merge(dst, src)
+}
+func (m *DeleteDraftResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteDraftResponse.Size(m)
+}
+func (m *DeleteDraftResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDraftResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteDraftResponse proto.InternalMessageInfo
+
+// PublishDraftRequest creates comment from draft as CreateComment would and deletes the draft
+type PublishDraftRequest struct {
+	UserUid              string   `protobuf:"bytes,1,opt,name=userUid,proto3" json:"userUid,omitempty"`
+	PostUid              string   `protobuf:"bytes,2,opt,name=postUid,proto3" json:"postUid,omitempty"`
+	ResourceType         string   `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	ResourceId           string   `protobuf:"bytes,4,opt,name=resourceId,proto3" json:"resourceId,omitempty"`
+	ParentUid            string   `protobuf:"bytes,5,opt,name=parentUid,proto3" json:"parentUid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishDraftRequest) Reset()         { *m = PublishDraftRequest{} }
+func (m *PublishDraftRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishDraftRequest) ProtoMessage()    {}
+func (*PublishDraftRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_comment_d4f57d7704e6aa96, []int{41}
+}
+func (m *PublishDraftRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishDraftRequest.Unmarshal(m, b)
+}
+func (m *PublishDraftRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishDraftRequest.Marshal(b, m, deterministic)
+}
+func (dst *PublishDraftRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishDraftRequest.Merge(dst, src)
+}
+func (m *PublishDraftRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishDraftRequest.Size(m)
+}
+func (m *PublishDraftRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishDraftRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishDraftRequest proto.InternalMessageInfo
+
+func (m *PublishDraftRequest) GetUserUid() string {
+	if m != nil {
+		return m.UserUid
+	}
+	return ""
+}
+
+func (m *PublishDraftRequest) GetPostUid() string {
+	if m != nil {
+		return m.PostUid
+	}
+	return ""
+}
+
+func (m *PublishDraftRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *PublishDraftRequest) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *PublishDraftRequest) GetParentUid() string {
+	if m != nil {
+		return m.ParentUid
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ListCommentsRequest)(nil), "comment.ListCommentsRequest")
+	proto.RegisterType((*ListCommentsResponse)(nil), "comment.ListCommentsResponse")
+	proto.RegisterType((*SingleComment)(nil), "comment.SingleComment")
+	proto.RegisterType((*GetCommentRequest)(nil), "comment.GetCommentRequest")
+	proto.RegisterType((*CreateCommentRequest)(nil), "comment.CreateCommentRequest")
+	proto.RegisterType((*Guest)(nil), "comment.Guest")
+	proto.RegisterType((*UpdateCommentRequest)(nil), "comment.UpdateCommentRequest")
+	proto.RegisterType((*RemoveContentRequest)(nil), "comment.RemoveContentRequest")
+	proto.RegisterType((*RemoveContentResponse)(nil), "comment.RemoveContentResponse")
+	proto.RegisterType((*RestoreContentRequest)(nil), "comment.RestoreContentRequest")
+	proto.RegisterType((*RestoreContentResponse)(nil), "comment.RestoreContentResponse")
+	proto.RegisterType((*DeleteCommentRequest)(nil), "comment.DeleteCommentRequest")
+	proto.RegisterType((*DeleteCommentResponse)(nil), "comment.DeleteCommentResponse")
+	proto.RegisterType((*GetOwnerRequest)(nil), "comment.GetOwnerRequest")
+	proto.RegisterType((*GetOwnerResponse)(nil), "comment.GetOwnerResponse")
+	proto.RegisterType((*CommentRevision)(nil), "comment.CommentRevision")
+	proto.RegisterType((*ExportUserDataRequest)(nil), "comment.ExportUserDataRequest")
+	proto.RegisterType((*UserDataRecord)(nil), "comment.UserDataRecord")
+	proto.RegisterType((*EraseUserRequest)(nil), "comment.EraseUserRequest")
+	proto.RegisterType((*EraseUserResponse)(nil), "comment.EraseUserResponse")
+	proto.RegisterType((*DeleteCommentsForPostRequest)(nil), "comment.DeleteCommentsForPostRequest")
+	proto.RegisterType((*DeleteCommentsForPostResponse)(nil), "comment.DeleteCommentsForPostResponse")
+	proto.RegisterType((*RemoveContentForPostRequest)(nil), "comment.RemoveContentForPostRequest")
+	proto.RegisterType((*RemoveContentForPostResponse)(nil), "comment.RemoveContentForPostResponse")
+	proto.RegisterType((*GetChallengeRequest)(nil), "comment.GetChallengeRequest")
+	proto.RegisterType((*Challenge)(nil), "comment.Challenge")
+	proto.RegisterType((*ListPendingCommentsRequest)(nil), "comment.ListPendingCommentsRequest")
+	proto.RegisterType((*ListRemovedCommentsRequest)(nil), "comment.ListRemovedCommentsRequest")
+	proto.RegisterType((*ListRemovalReasonsRequest)(nil), "comment.ListRemovalReasonsRequest")
+	proto.RegisterType((*RemovalReason)(nil), "comment.RemovalReason")
+	proto.RegisterType((*ListRemovalReasonsResponse)(nil), "comment.ListRemovalReasonsResponse")
+	proto.RegisterType((*ApproveCommentRequest)(nil), "comment.ApproveCommentRequest")
+	proto.RegisterType((*ClaimGuestCommentsRequest)(nil), "comment.ClaimGuestCommentsRequest")
+	proto.RegisterType((*ClaimGuestCommentsResponse)(nil), "comment.ClaimGuestCommentsResponse")
+	proto.RegisterType((*Draft)(nil), "comment.Draft")
+	proto.RegisterType((*SaveDraftRequest)(nil), "comment.SaveDraftRequest")
+	proto.RegisterType((*GetDraftRequest)(nil), "comment.GetDraftRequest")
+	proto.RegisterType((*ListDraftsRequest)(nil), "comment.ListDraftsRequest")
+	proto.RegisterType((*ListDraftsResponse)(nil), "comment.ListDraftsResponse")
+	proto.RegisterType((*DeleteDraftRequest)(nil), "comment.DeleteDraftRequest")
+	proto.RegisterType((*DeleteDraftResponse)(nil), "comment.DeleteDraftResponse")
+	proto.RegisterType((*PublishDraftRequest)(nil), "comment.PublishDraftRequest")
+	proto.RegisterEnum("comment.Remover", Remover_name, Remover_value)
+	proto.RegisterEnum("comment.DeleteMode", DeleteMode_name, DeleteMode_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CommentClient is the client API for Comment service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CommentClient interface {
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*SingleComment, error)
+	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*SingleComment, error)
+	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*SingleComment, error)
+	RemoveContent(ctx context.Context, in *RemoveContentRequest, opts ...grpc.CallOption) (*RemoveContentResponse, error)
+	RestoreContent(ctx context.Context, in *RestoreContentRequest, opts ...grpc.CallOption) (*RestoreContentResponse, error)
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+	GetOwner(ctx context.Context, in *GetOwnerRequest, opts ...grpc.CallOption) (*GetOwnerResponse, error)
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (Comment_ExportUserDataClient, error)
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error)
+	DeleteCommentsForPost(ctx context.Context, in *DeleteCommentsForPostRequest, opts ...grpc.CallOption) (*DeleteCommentsForPostResponse, error)
+	RemoveContentForPost(ctx context.Context, in *RemoveContentForPostRequest, opts ...grpc.CallOption) (*RemoveContentForPostResponse, error)
+	GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
+	ListPendingComments(ctx context.Context, in *ListPendingCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	ApproveComment(ctx context.Context, in *ApproveCommentRequest, opts ...grpc.CallOption) (*SingleComment, error)
+	ListRemovedComments(ctx context.Context, in *ListRemovedCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	ListRemovalReasons(ctx context.Context, in *ListRemovalReasonsRequest, opts ...grpc.CallOption) (*ListRemovalReasonsResponse, error)
+	ClaimGuestComments(ctx context.Context, in *ClaimGuestCommentsRequest, opts ...grpc.CallOption) (*ClaimGuestCommentsResponse, error)
+	SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*Draft, error)
+	GetDraft(ctx context.Context, in *GetDraftRequest, opts ...grpc.CallOption) (*Draft, error)
+	ListDrafts(ctx context.Context, in *ListDraftsRequest, opts ...grpc.CallOption) (*ListDraftsResponse, error)
+	DeleteDraft(ctx context.Context, in *DeleteDraftRequest, opts ...grpc.CallOption) (*DeleteDraftResponse, error)
+	PublishDraft(ctx context.Context, in *PublishDraftRequest, opts ...grpc.CallOption) (*SingleComment, error)
+}
+
+type commentClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCommentClient(cc *grpc.ClientConn) CommentClient {
 	return &commentClient{cc}
 }
 
@@ -676,54 +2497,221 @@ func (c *commentClient) ListComments(ctx context.Context, in *ListCommentsReques
 	return out, nil
 }
 
-func (c *commentClient) GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*SingleComment, error) {
-	out := new(SingleComment)
-	err := c.cc.Invoke(ctx, "/comment.Comment/GetComment", in, out, opts...)
+func (c *commentClient) GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*SingleComment, error) {
+	out := new(SingleComment)
+	err := c.cc.Invoke(ctx, "/comment.Comment/GetComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*SingleComment, error) {
+	out := new(SingleComment)
+	err := c.cc.Invoke(ctx, "/comment.Comment/CreateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*SingleComment, error) {
+	out := new(SingleComment)
+	err := c.cc.Invoke(ctx, "/comment.Comment/UpdateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) RemoveContent(ctx context.Context, in *RemoveContentRequest, opts ...grpc.CallOption) (*RemoveContentResponse, error) {
+	out := new(RemoveContentResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/RemoveContent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) RestoreContent(ctx context.Context, in *RestoreContentRequest, opts ...grpc.CallOption) (*RestoreContentResponse, error) {
+	out := new(RestoreContentResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/RestoreContent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/DeleteComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) GetOwner(ctx context.Context, in *GetOwnerRequest, opts ...grpc.CallOption) (*GetOwnerResponse, error) {
+	out := new(GetOwnerResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/GetOwner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (Comment_ExportUserDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Comment_serviceDesc.Streams[0], "/comment.Comment/ExportUserData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &commentExportUserDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Comment_ExportUserDataClient interface {
+	Recv() (*UserDataRecord, error)
+	grpc.ClientStream
+}
+
+type commentExportUserDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *commentExportUserDataClient) Recv() (*UserDataRecord, error) {
+	m := new(UserDataRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *commentClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error) {
+	out := new(EraseUserResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/EraseUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) DeleteCommentsForPost(ctx context.Context, in *DeleteCommentsForPostRequest, opts ...grpc.CallOption) (*DeleteCommentsForPostResponse, error) {
+	out := new(DeleteCommentsForPostResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/DeleteCommentsForPost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) RemoveContentForPost(ctx context.Context, in *RemoveContentForPostRequest, opts ...grpc.CallOption) (*RemoveContentForPostResponse, error) {
+	out := new(RemoveContentForPostResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/RemoveContentForPost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, "/comment.Comment/GetChallenge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) ListPendingComments(ctx context.Context, in *ListPendingCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/ListPendingComments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) ApproveComment(ctx context.Context, in *ApproveCommentRequest, opts ...grpc.CallOption) (*SingleComment, error) {
+	out := new(SingleComment)
+	err := c.cc.Invoke(ctx, "/comment.Comment/ApproveComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) ListRemovedComments(ctx context.Context, in *ListRemovedCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/ListRemovedComments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) ListRemovalReasons(ctx context.Context, in *ListRemovalReasonsRequest, opts ...grpc.CallOption) (*ListRemovalReasonsResponse, error) {
+	out := new(ListRemovalReasonsResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/ListRemovalReasons", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentClient) ClaimGuestComments(ctx context.Context, in *ClaimGuestCommentsRequest, opts ...grpc.CallOption) (*ClaimGuestCommentsResponse, error) {
+	out := new(ClaimGuestCommentsResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/ClaimGuestComments", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *commentClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*SingleComment, error) {
-	out := new(SingleComment)
-	err := c.cc.Invoke(ctx, "/comment.Comment/CreateComment", in, out, opts...)
+func (c *commentClient) SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*Draft, error) {
+	out := new(Draft)
+	err := c.cc.Invoke(ctx, "/comment.Comment/SaveDraft", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *commentClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error) {
-	out := new(UpdateCommentResponse)
-	err := c.cc.Invoke(ctx, "/comment.Comment/UpdateComment", in, out, opts...)
+func (c *commentClient) GetDraft(ctx context.Context, in *GetDraftRequest, opts ...grpc.CallOption) (*Draft, error) {
+	out := new(Draft)
+	err := c.cc.Invoke(ctx, "/comment.Comment/GetDraft", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *commentClient) RemoveContent(ctx context.Context, in *RemoveContentRequest, opts ...grpc.CallOption) (*RemoveContentResponse, error) {
-	out := new(RemoveContentResponse)
-	err := c.cc.Invoke(ctx, "/comment.Comment/RemoveContent", in, out, opts...)
+func (c *commentClient) ListDrafts(ctx context.Context, in *ListDraftsRequest, opts ...grpc.CallOption) (*ListDraftsResponse, error) {
+	out := new(ListDraftsResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/ListDrafts", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *commentClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
-	out := new(DeleteCommentResponse)
-	err := c.cc.Invoke(ctx, "/comment.Comment/DeleteComment", in, out, opts...)
+func (c *commentClient) DeleteDraft(ctx context.Context, in *DeleteDraftRequest, opts ...grpc.CallOption) (*DeleteDraftResponse, error) {
+	out := new(DeleteDraftResponse)
+	err := c.cc.Invoke(ctx, "/comment.Comment/DeleteDraft", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *commentClient) GetOwner(ctx context.Context, in *GetOwnerRequest, opts ...grpc.CallOption) (*GetOwnerResponse, error) {
-	out := new(GetOwnerResponse)
-	err := c.cc.Invoke(ctx, "/comment.Comment/GetOwner", in, out, opts...)
+func (c *commentClient) PublishDraft(ctx context.Context, in *PublishDraftRequest, opts ...grpc.CallOption) (*SingleComment, error) {
+	out := new(SingleComment)
+	err := c.cc.Invoke(ctx, "/comment.Comment/PublishDraft", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -735,10 +2723,26 @@ type CommentServer interface {
 	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
 	GetComment(context.Context, *GetCommentRequest) (*SingleComment, error)
 	CreateComment(context.Context, *CreateCommentRequest) (*SingleComment, error)
-	UpdateComment(context.Context, *UpdateCommentRequest) (*UpdateCommentResponse, error)
+	UpdateComment(context.Context, *UpdateCommentRequest) (*SingleComment, error)
 	RemoveContent(context.Context, *RemoveContentRequest) (*RemoveContentResponse, error)
+	RestoreContent(context.Context, *RestoreContentRequest) (*RestoreContentResponse, error)
 	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
 	GetOwner(context.Context, *GetOwnerRequest) (*GetOwnerResponse, error)
+	ExportUserData(*ExportUserDataRequest, Comment_ExportUserDataServer) error
+	EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error)
+	DeleteCommentsForPost(context.Context, *DeleteCommentsForPostRequest) (*DeleteCommentsForPostResponse, error)
+	RemoveContentForPost(context.Context, *RemoveContentForPostRequest) (*RemoveContentForPostResponse, error)
+	GetChallenge(context.Context, *GetChallengeRequest) (*Challenge, error)
+	ListPendingComments(context.Context, *ListPendingCommentsRequest) (*ListCommentsResponse, error)
+	ApproveComment(context.Context, *ApproveCommentRequest) (*SingleComment, error)
+	ListRemovedComments(context.Context, *ListRemovedCommentsRequest) (*ListCommentsResponse, error)
+	ListRemovalReasons(context.Context, *ListRemovalReasonsRequest) (*ListRemovalReasonsResponse, error)
+	ClaimGuestComments(context.Context, *ClaimGuestCommentsRequest) (*ClaimGuestCommentsResponse, error)
+	SaveDraft(context.Context, *SaveDraftRequest) (*Draft, error)
+	GetDraft(context.Context, *GetDraftRequest) (*Draft, error)
+	ListDrafts(context.Context, *ListDraftsRequest) (*ListDraftsResponse, error)
+	DeleteDraft(context.Context, *DeleteDraftRequest) (*DeleteDraftResponse, error)
+	PublishDraft(context.Context, *PublishDraftRequest) (*SingleComment, error)
 }
 
 func RegisterCommentServer(s *grpc.Server, srv CommentServer) {
@@ -835,6 +2839,24 @@ func _Comment_RemoveContent_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Comment_RestoreContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).RestoreContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/RestoreContent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).RestoreContent(ctx, req.(*RestoreContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Comment_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteCommentRequest)
 	if err := dec(in); err != nil {
@@ -871,6 +2893,279 @@ func _Comment_GetOwner_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Comment_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommentServer).ExportUserData(m, &commentExportUserDataServer{stream})
+}
+
+type Comment_ExportUserDataServer interface {
+	Send(*UserDataRecord) error
+	grpc.ServerStream
+}
+
+type commentExportUserDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *commentExportUserDataServer) Send(m *UserDataRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Comment_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/EraseUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).EraseUser(ctx, req.(*EraseUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_DeleteCommentsForPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentsForPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).DeleteCommentsForPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/DeleteCommentsForPost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).DeleteCommentsForPost(ctx, req.(*DeleteCommentsForPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_RemoveContentForPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveContentForPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).RemoveContentForPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/RemoveContentForPost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).RemoveContentForPost(ctx, req.(*RemoveContentForPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_GetChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).GetChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/GetChallenge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).GetChallenge(ctx, req.(*GetChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_ListPendingComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).ListPendingComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/ListPendingComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).ListPendingComments(ctx, req.(*ListPendingCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_ApproveComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).ApproveComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/ApproveComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).ApproveComment(ctx, req.(*ApproveCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_ListRemovedComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRemovedCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).ListRemovedComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/ListRemovedComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).ListRemovedComments(ctx, req.(*ListRemovedCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_ListRemovalReasons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRemovalReasonsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).ListRemovalReasons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/ListRemovalReasons",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).ListRemovalReasons(ctx, req.(*ListRemovalReasonsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_ClaimGuestComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimGuestCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).ClaimGuestComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/ClaimGuestComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).ClaimGuestComments(ctx, req.(*ClaimGuestCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).SaveDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/SaveDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).SaveDraft(ctx, req.(*SaveDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_GetDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).GetDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/GetDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).GetDraft(ctx, req.(*GetDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_ListDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDraftsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).ListDrafts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/ListDrafts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).ListDrafts(ctx, req.(*ListDraftsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_DeleteDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).DeleteDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/DeleteDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).DeleteDraft(ctx, req.(*DeleteDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comment_PublishDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServer).PublishDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comment.Comment/PublishDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServer).PublishDraft(ctx, req.(*PublishDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Comment_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "comment.Comment",
 	HandlerType: (*CommentServer)(nil),
@@ -895,6 +3190,10 @@ var _Comment_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveContent",
 			Handler:    _Comment_RemoveContent_Handler,
 		},
+		{
+			MethodName: "RestoreContent",
+			Handler:    _Comment_RestoreContent_Handler,
+		},
 		{
 			MethodName: "DeleteComment",
 			Handler:    _Comment_DeleteComment_Handler,
@@ -903,51 +3202,214 @@ var _Comment_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetOwner",
 			Handler:    _Comment_GetOwner_Handler,
 		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _Comment_EraseUser_Handler,
+		},
+		{
+			MethodName: "DeleteCommentsForPost",
+			Handler:    _Comment_DeleteCommentsForPost_Handler,
+		},
+		{
+			MethodName: "RemoveContentForPost",
+			Handler:    _Comment_RemoveContentForPost_Handler,
+		},
+		{
+			MethodName: "GetChallenge",
+			Handler:    _Comment_GetChallenge_Handler,
+		},
+		{
+			MethodName: "ListPendingComments",
+			Handler:    _Comment_ListPendingComments_Handler,
+		},
+		{
+			MethodName: "ApproveComment",
+			Handler:    _Comment_ApproveComment_Handler,
+		},
+		{
+			MethodName: "ListRemovedComments",
+			Handler:    _Comment_ListRemovedComments_Handler,
+		},
+		{
+			MethodName: "ListRemovalReasons",
+			Handler:    _Comment_ListRemovalReasons_Handler,
+		},
+		{
+			MethodName: "ClaimGuestComments",
+			Handler:    _Comment_ClaimGuestComments_Handler,
+		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _Comment_SaveDraft_Handler,
+		},
+		{
+			MethodName: "GetDraft",
+			Handler:    _Comment_GetDraft_Handler,
+		},
+		{
+			MethodName: "ListDrafts",
+			Handler:    _Comment_ListDrafts_Handler,
+		},
+		{
+			MethodName: "DeleteDraft",
+			Handler:    _Comment_DeleteDraft_Handler,
+		},
+		{
+			MethodName: "PublishDraft",
+			Handler:    _Comment_PublishDraft_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _Comment_ExportUserData_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/comment/proto/comment.proto",
 }
 
 func init() {
-	proto.RegisterFile("pkg/comment/proto/comment.proto", fileDescriptor_comment_58f34ab1f7605aa0)
-}
-
-var fileDescriptor_comment_58f34ab1f7605aa0 = []byte{
-	// 566 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0xe3, 0xb4, 0x71, 0xa6, 0x44, 0x94, 0x25, 0x25, 0xc6, 0x2a, 0xad, 0x65, 0x84, 0xe4,
-	0x93, 0x23, 0x85, 0x0b, 0x42, 0x48, 0x80, 0x82, 0xe8, 0x01, 0x14, 0x24, 0x97, 0xfe, 0x80, 0xa4,
-	0x9e, 0x5a, 0x16, 0xb1, 0xd7, 0x78, 0xd7, 0x20, 0x10, 0x67, 0x2e, 0x9c, 0x38, 0xf3, 0x67, 0x91,
-	0x3f, 0xd6, 0x5e, 0x7f, 0xb5, 0x37, 0xcf, 0xce, 0xcc, 0x9b, 0xd9, 0xf7, 0xde, 0x1a, 0xce, 0xe3,
-	0x2f, 0xfe, 0xf2, 0x9a, 0x86, 0x21, 0x46, 0x7c, 0x19, 0x27, 0x94, 0x53, 0x11, 0x39, 0x79, 0x44,
-	0x26, 0x65, 0x68, 0x9c, 0xfb, 0x94, 0xfa, 0x7b, 0x2c, 0x8a, 0x76, 0xe9, 0xcd, 0x92, 0x07, 0x21,
-	0x32, 0xbe, 0x0d, 0xe3, 0xa2, 0xd2, 0xfa, 0xa3, 0xc0, 0xc3, 0x8f, 0x01, 0xe3, 0xeb, 0xa2, 0x81,
-	0xb9, 0xf8, 0x35, 0x45, 0xc6, 0x89, 0x0e, 0x93, 0x98, 0x32, 0x7e, 0x15, 0x78, 0xba, 0x62, 0x2a,
-	0xf6, 0xd4, 0x15, 0x21, 0x39, 0x03, 0x28, 0xd1, 0xb3, 0xe4, 0x28, 0x4f, 0x4a, 0x27, 0xc4, 0x00,
-	0x2d, 0xde, 0xfa, 0x78, 0x19, 0xfc, 0x44, 0x5d, 0x35, 0x15, 0xfb, 0xc0, 0xad, 0xe2, 0xac, 0x37,
-	0xfb, 0xde, 0xa4, 0xe1, 0x0e, 0x13, 0x7d, 0x9c, 0x67, 0xa5, 0x13, 0xeb, 0xb7, 0x02, 0xf3, 0xe6,
-	0x36, 0x2c, 0xa6, 0x11, 0x43, 0xb2, 0x02, 0xad, 0x1c, 0xc1, 0x74, 0xc5, 0x54, 0xed, 0xa3, 0xd5,
-	0x23, 0x47, 0x5c, 0xf9, 0x32, 0x88, 0xfc, 0x3d, 0x96, 0x2d, 0x6e, 0x55, 0xd7, 0x58, 0x64, 0x74,
-	0xeb, 0x22, 0x6a, 0x67, 0x91, 0x7f, 0x23, 0x98, 0x35, 0x70, 0xc9, 0x31, 0xa8, 0x69, 0x45, 0x46,
-	0xf6, 0x99, 0x51, 0x94, 0x32, 0x4c, 0x6a, 0x16, 0x44, 0x28, 0x93, 0xa7, 0x36, 0xc9, 0x23, 0x30,
-	0xde, 0x51, 0xef, 0x47, 0x7e, 0xf5, 0xa9, 0x9b, 0x7f, 0x93, 0x53, 0x98, 0xc6, 0xdb, 0xa4, 0xe4,
-	0xf3, 0x20, 0x4f, 0xd4, 0x07, 0xe4, 0x05, 0x4c, 0xaf, 0x13, 0xdc, 0x72, 0xf4, 0xde, 0x72, 0xfd,
-	0xd0, 0x54, 0xec, 0xa3, 0x95, 0xe1, 0x14, 0xaa, 0x3a, 0x42, 0x55, 0xe7, 0xb3, 0x50, 0xd5, 0xad,
-	0x8b, 0xc9, 0x4b, 0x80, 0x90, 0x7a, 0xc1, 0x4d, 0x90, 0xb7, 0x4e, 0xee, 0x6c, 0x95, 0xaa, 0xb3,
-	0x9d, 0x02, 0xf6, 0x0e, 0xf7, 0xc8, 0xd1, 0xd3, 0x35, 0x53, 0xb1, 0x35, 0xb7, 0x3e, 0xb0, 0x9e,
-	0xc1, 0x83, 0x0b, 0x14, 0x22, 0x09, 0xc7, 0x74, 0x08, 0xb2, 0x7e, 0xc1, 0x7c, 0x9d, 0x6f, 0xd3,
-	0xaa, 0x1c, 0xf6, 0x96, 0xa0, 0x67, 0x34, 0x44, 0x8f, 0xda, 0xa6, 0x47, 0x12, 0x61, 0xdc, 0x10,
-	0xc1, 0x7a, 0x05, 0xf3, 0xab, 0xd8, 0xeb, 0x4e, 0xef, 0x0a, 0xd9, 0x33, 0xd5, 0x5a, 0xc0, 0x49,
-	0xab, 0xbb, 0x70, 0xa2, 0x65, 0xc3, 0xdc, 0xc5, 0x90, 0x7e, 0xc3, 0x35, 0x8d, 0xf8, 0xad, 0xd7,
-	0x5f, 0xc0, 0x49, 0xab, 0xb2, 0x86, 0x28, 0x98, 0xbc, 0x93, 0xc1, 0x05, 0x9c, 0xb4, 0x2a, 0x4b,
-	0x88, 0xa7, 0x70, 0xff, 0x02, 0xf9, 0xa7, 0xef, 0x11, 0x26, 0xc3, 0xdd, 0x0e, 0x1c, 0xd7, 0x45,
-	0xe5, 0x43, 0x32, 0x40, 0xa3, 0xd9, 0x41, 0x4d, 0x7e, 0x15, 0xaf, 0xfe, 0x8e, 0x61, 0x22, 0xec,
-	0xfe, 0x01, 0xee, 0xc9, 0x0f, 0x91, 0x9c, 0x56, 0xcf, 0xad, 0xe7, 0x6f, 0x61, 0x3c, 0x19, 0xc8,
-	0x96, 0x43, 0xdf, 0x00, 0xd4, 0x7e, 0x21, 0x46, 0x55, 0xdc, 0x31, 0x91, 0x31, 0xf0, 0xaa, 0xc9,
-	0x7b, 0x98, 0x35, 0xac, 0x44, 0xea, 0x89, 0x7d, 0x16, 0x1b, 0xc4, 0xd9, 0xc0, 0xac, 0x21, 0xab,
-	0x84, 0xd3, 0x67, 0x16, 0xe3, 0x6c, 0x28, 0x5d, 0xde, 0x6c, 0x03, 0xb3, 0x86, 0xc6, 0x12, 0x5e,
-	0x9f, 0x4b, 0x24, 0xbc, 0x5e, 0x6b, 0x64, 0x78, 0x0d, 0xc1, 0x25, 0xbc, 0x3e, 0xcb, 0x48, 0x78,
-	0xbd, 0x3e, 0x21, 0xaf, 0x41, 0x13, 0x16, 0x20, 0xba, 0xcc, 0xbb, 0x6c, 0x1d, 0xe3, 0x71, 0x4f,
-	0xa6, 0x00, 0xd8, 0x1d, 0xe6, 0x3f, 0x8a, 0xe7, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x46, 0x9b,
-	0xee, 0x34, 0x73, 0x06, 0x00, 0x00,
+	proto.RegisterFile("pkg/comment/proto/comment.proto", fileDescriptor_comment_d4f57d7704e6aa96)
+}
+
+var fileDescriptor_comment_d4f57d7704e6aa96 = []byte{
+	// 2187 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x19, 0x5d, 0x6f, 0xe3, 0x58,
+	0x75, 0x9d, 0xa4, 0x1f, 0x39, 0x6d, 0x3a, 0xe9, 0xed, 0x97, 0xeb, 0x76, 0x66, 0xba, 0x77, 0x66,
+	0x87, 0x12, 0xb1, 0xcd, 0x4c, 0x97, 0x85, 0xa5, 0x2b, 0xa1, 0xed, 0xa6, 0xe9, 0x6c, 0x61, 0xfb,
+	0x21, 0x27, 0x45, 0x6c, 0x91, 0xb6, 0x72, 0xe3, 0xdb, 0x8e, 0x99, 0xc4, 0xf6, 0xda, 0xce, 0xcc,
+	0x74, 0x47, 0x95, 0x10, 0x08, 0xc4, 0x03, 0x2f, 0x88, 0x27, 0x90, 0x90, 0x10, 0x2f, 0x48, 0xf0,
+	0xb0, 0x7f, 0x60, 0x7f, 0x00, 0x3c, 0xed, 0x03, 0xbf, 0x00, 0x09, 0xf1, 0x37, 0x40, 0xf7, 0xc3,
+	0xf6, 0xb5, 0x63, 0x37, 0x93, 0xd9, 0x79, 0x18, 0xde, 0x72, 0xcf, 0x39, 0xbe, 0xe7, 0xfb, 0xdc,
+	0x73, 0x4e, 0xe0, 0xb6, 0xfb, 0xf8, 0xa2, 0xde, 0x71, 0x7a, 0x3d, 0x62, 0x07, 0x75, 0xd7, 0x73,
+	0x02, 0x27, 0x3c, 0x6d, 0xb0, 0x13, 0x9a, 0x10, 0x47, 0x6d, 0xf5, 0xc2, 0x71, 0x2e, 0xba, 0xa4,
+	0x6e, 0xb8, 0x56, 0xdd, 0xb0, 0x6d, 0x27, 0x30, 0x02, 0xcb, 0xb1, 0x7d, 0x4e, 0xa6, 0xad, 0x09,
+	0x2c, 0x3b, 0x9d, 0xf5, 0xcf, 0xeb, 0xe7, 0x16, 0xe9, 0x9a, 0xa7, 0x3d, 0xc3, 0x7f, 0x2c, 0x28,
+	0x6e, 0xa7, 0x29, 0x02, 0xab, 0x47, 0xfc, 0xc0, 0xe8, 0xb9, 0x9c, 0x00, 0xff, 0x57, 0x81, 0xb9,
+	0x8f, 0x2d, 0x3f, 0x68, 0x70, 0x86, 0xbe, 0x4e, 0x3e, 0xeb, 0x13, 0x3f, 0x40, 0x2a, 0x4c, 0xb8,
+	0x8e, 0x1f, 0x1c, 0x5b, 0xa6, 0xaa, 0xac, 0x29, 0xeb, 0x65, 0x3d, 0x3c, 0xa2, 0x5b, 0x00, 0x42,
+	0x3a, 0x8a, 0x2c, 0x30, 0xa4, 0x04, 0x41, 0x1a, 0x4c, 0xba, 0xc6, 0x05, 0x69, 0x59, 0x9f, 0x13,
+	0xb5, 0xb8, 0xa6, 0xac, 0x8f, 0xe9, 0xd1, 0x99, 0x7e, 0x4b, 0x7f, 0x1f, 0xf4, 0x7b, 0x67, 0xc4,
+	0x53, 0x4b, 0x0c, 0x2b, 0x41, 0x10, 0x86, 0x69, 0x8f, 0xf8, 0x4e, 0xdf, 0xeb, 0x90, 0xf6, 0xa5,
+	0x4b, 0xd4, 0x31, 0x76, 0x7b, 0x02, 0x46, 0xef, 0x08, 0xcf, 0x7b, 0xa6, 0x3a, 0xce, 0xf9, 0xc7,
+	0x10, 0xb4, 0x01, 0xc8, 0xb2, 0x3b, 0xdd, 0xbe, 0x49, 0x74, 0xd2, 0x73, 0x9e, 0x10, 0xf3, 0x43,
+	0xc7, 0xbc, 0x54, 0x27, 0xd6, 0x94, 0xf5, 0x49, 0x3d, 0x03, 0x83, 0x7f, 0xa5, 0xc0, 0x7c, 0xd2,
+	0x02, 0xbe, 0xeb, 0xd8, 0x3e, 0x41, 0x9b, 0x30, 0x29, 0xd4, 0xf2, 0x55, 0x65, 0xad, 0xb8, 0x3e,
+	0xb5, 0xb9, 0xb8, 0x11, 0xba, 0xa9, 0x65, 0xd9, 0x17, 0x5d, 0x22, 0x3e, 0xd1, 0x23, 0xba, 0x84,
+	0xf2, 0x85, 0x6b, 0x95, 0x2f, 0xa6, 0x95, 0xc7, 0x5f, 0x95, 0xa0, 0x92, 0xb8, 0x17, 0x55, 0xa1,
+	0xd8, 0x8f, 0x1c, 0x40, 0x7f, 0x52, 0xb7, 0xf4, 0x7d, 0xe2, 0xc5, 0x96, 0x0f, 0x8f, 0xb2, 0xc3,
+	0x8a, 0x49, 0x87, 0x21, 0x28, 0x9d, 0x51, 0x13, 0x94, 0x18, 0x98, 0xfd, 0x46, 0xab, 0x50, 0x76,
+	0x0d, 0x4f, 0xf8, 0x90, 0x5b, 0x39, 0x06, 0xa0, 0xf7, 0xa0, 0xdc, 0xf1, 0x88, 0x11, 0x10, 0x73,
+	0x3b, 0x60, 0x16, 0x9e, 0xda, 0xd4, 0x36, 0x78, 0x24, 0x6d, 0x84, 0x91, 0xb4, 0xd1, 0x0e, 0x23,
+	0x49, 0x8f, 0x89, 0xd1, 0x16, 0x40, 0xcf, 0x31, 0xad, 0x73, 0x8b, 0x7d, 0x3a, 0x31, 0xf4, 0x53,
+	0x89, 0x9a, 0xca, 0x64, 0xf9, 0x3b, 0xa4, 0x4b, 0x02, 0x62, 0xaa, 0x93, 0xcc, 0x5f, 0x31, 0x60,
+	0x20, 0x34, 0xca, 0x43, 0x43, 0x03, 0x06, 0x42, 0x63, 0x15, 0xca, 0x17, 0x34, 0xba, 0x0f, 0x8c,
+	0x1e, 0x51, 0xa7, 0xb8, 0xd6, 0x11, 0x80, 0xf3, 0x3f, 0x22, 0xb6, 0x69, 0xd9, 0x17, 0xea, 0x74,
+	0xc8, 0x5f, 0x00, 0xd0, 0x06, 0x94, 0x3d, 0x11, 0x35, 0x97, 0x6a, 0x65, 0x4d, 0x59, 0x9f, 0xd9,
+	0xac, 0x46, 0xe1, 0xc0, 0xe3, 0xc9, 0xd3, 0x63, 0x12, 0x74, 0x17, 0x2a, 0xec, 0x60, 0x74, 0x75,
+	0x62, 0xf8, 0x8e, 0xad, 0xce, 0x30, 0x7e, 0x49, 0x20, 0xd7, 0x8a, 0x01, 0x0e, 0x9c, 0x80, 0xf8,
+	0xea, 0x8d, 0x50, 0xab, 0x18, 0x46, 0xbd, 0x21, 0xae, 0xdd, 0x0e, 0xd4, 0xea, 0x70, 0x6f, 0x44,
+	0xc4, 0xf8, 0x18, 0x66, 0x1f, 0x92, 0x30, 0xb0, 0xc3, 0xcc, 0x1e, 0x0c, 0xaa, 0xec, 0x8c, 0x29,
+	0xe4, 0x66, 0xcc, 0x7f, 0x14, 0x98, 0x6f, 0x30, 0x97, 0xa7, 0xae, 0xce, 0x2f, 0x1a, 0x61, 0x0c,
+	0x16, 0xf2, 0x62, 0xb0, 0x98, 0x8e, 0x41, 0x29, 0xd2, 0x4b, 0xc9, 0x48, 0x7f, 0x15, 0x45, 0xe2,
+	0x2e, 0x8c, 0x31, 0xc7, 0x8b, 0x10, 0x9d, 0x89, 0x3c, 0xf9, 0x90, 0x42, 0x75, 0x8e, 0xc4, 0xbf,
+	0x50, 0x60, 0x8c, 0x01, 0xd0, 0x1a, 0x4c, 0x99, 0x96, 0xef, 0x76, 0x8d, 0x4b, 0x16, 0x3b, 0x5c,
+	0x3b, 0x19, 0x84, 0xe6, 0x61, 0x8c, 0xf4, 0x0c, 0xab, 0x2b, 0x54, 0xe4, 0x07, 0x74, 0x0f, 0x66,
+	0x3a, 0x8f, 0x8c, 0x6e, 0x97, 0xd8, 0x17, 0xa4, 0xed, 0x3c, 0x26, 0xb6, 0x50, 0x34, 0x05, 0xa5,
+	0x75, 0xc3, 0x77, 0xba, 0x7d, 0x5a, 0xdc, 0x85, 0xba, 0xd1, 0x19, 0x3f, 0x83, 0xf9, 0x63, 0xd7,
+	0x1c, 0xb4, 0xf6, 0xa0, 0x23, 0xb3, 0xac, 0xbc, 0x05, 0xd0, 0x67, 0x5f, 0xef, 0x1b, 0xfe, 0x63,
+	0xb5, 0x98, 0x13, 0x3e, 0xbb, 0xf4, 0xe1, 0xa0, 0x14, 0xba, 0x44, 0xcd, 0x4a, 0x23, 0x77, 0x7c,
+	0xc3, 0xb1, 0x83, 0x21, 0x31, 0x24, 0xa5, 0x47, 0x61, 0x78, 0x7a, 0x2c, 0xc2, 0xb8, 0xc7, 0xf3,
+	0x82, 0x1b, 0x44, 0x9c, 0xa8, 0x19, 0x6d, 0x96, 0x09, 0xdc, 0x0a, 0xfc, 0x80, 0x97, 0x60, 0x21,
+	0x25, 0x07, 0xaf, 0xd1, 0xf8, 0x9b, 0x14, 0xe1, 0x07, 0x8e, 0x37, 0x54, 0x42, 0xac, 0xc2, 0x62,
+	0x9a, 0x54, 0x5c, 0xf2, 0x85, 0x02, 0xf3, 0xbc, 0xcc, 0x0c, 0xb5, 0xf0, 0x37, 0xa0, 0xd4, 0x73,
+	0x4c, 0x22, 0x34, 0x9c, 0x8b, 0x34, 0xe4, 0x9f, 0xef, 0x3b, 0x26, 0xd1, 0x19, 0x41, 0xd2, 0x1e,
+	0xc5, 0x51, 0xec, 0x51, 0xca, 0xb6, 0xc7, 0x98, 0x6c, 0x8f, 0x9f, 0xc0, 0x42, 0x4a, 0x60, 0xf1,
+	0x66, 0x61, 0x98, 0x36, 0x19, 0xc2, 0x6c, 0x38, 0x7d, 0x3b, 0x60, 0xa2, 0x17, 0xf5, 0x04, 0x8c,
+	0xe6, 0x46, 0xe0, 0xf4, 0xce, 0xfc, 0xc0, 0xb1, 0x89, 0x29, 0xd2, 0x5c, 0x82, 0xe0, 0x3b, 0x70,
+	0xe3, 0x21, 0x09, 0x0e, 0x9f, 0xda, 0xc4, 0xcb, 0xb7, 0xe6, 0x06, 0x54, 0x63, 0x22, 0xc1, 0x5c,
+	0x83, 0x49, 0xe7, 0xa9, 0xcd, 0x73, 0x96, 0x93, 0x46, 0x67, 0xfc, 0x5b, 0x05, 0x6e, 0x44, 0xc2,
+	0x3e, 0xb1, 0x7c, 0xcb, 0xb1, 0x33, 0xcc, 0x3b, 0xac, 0xb7, 0x08, 0x03, 0xbc, 0x28, 0x05, 0x78,
+	0xe2, 0xb1, 0x2a, 0x8d, 0xf0, 0x58, 0xe1, 0x07, 0xb0, 0xd0, 0x7c, 0xe6, 0x3a, 0x5e, 0x70, 0xec,
+	0x13, 0x6f, 0xc7, 0x08, 0x0c, 0xa9, 0x8e, 0x85, 0xb5, 0x47, 0x49, 0xd4, 0x1e, 0xfc, 0x39, 0xcc,
+	0xc4, 0xc4, 0x1d, 0xc7, 0x33, 0xd1, 0x7d, 0x08, 0x9b, 0x35, 0x46, 0x9b, 0xdf, 0x24, 0x84, 0x64,
+	0xe8, 0x3b, 0x34, 0x34, 0xb8, 0x09, 0x7c, 0xb5, 0xc0, 0x1a, 0x0b, 0x35, 0xfa, 0x26, 0x65, 0x23,
+	0x3d, 0x26, 0xc5, 0xdf, 0x82, 0x6a, 0xd3, 0x33, 0x7c, 0x42, 0x05, 0x18, 0x2e, 0xe9, 0xbb, 0x30,
+	0x2b, 0x51, 0x0b, 0x0f, 0xad, 0xc1, 0x14, 0xa1, 0xc0, 0x44, 0x74, 0xc8, 0x20, 0xfc, 0x07, 0x05,
+	0x56, 0x13, 0xa1, 0xe5, 0xef, 0x3a, 0xde, 0x91, 0xe3, 0xbf, 0x40, 0x8d, 0x5f, 0x85, 0xf2, 0x99,
+	0x11, 0x74, 0x1e, 0x49, 0xcd, 0x4f, 0x0c, 0x18, 0xa8, 0xda, 0xc5, 0xa1, 0x55, 0xbb, 0x94, 0xae,
+	0xda, 0xb8, 0x09, 0x37, 0x73, 0x64, 0x13, 0xfa, 0xdd, 0x85, 0x8a, 0x71, 0x7e, 0x4e, 0x3a, 0xa9,
+	0xf8, 0x4f, 0x02, 0xf1, 0xef, 0x15, 0x58, 0x49, 0x94, 0x93, 0xd7, 0x48, 0xc5, 0x1d, 0x58, 0xcd,
+	0x16, 0x6d, 0x24, 0x0d, 0x17, 0x60, 0x8e, 0x3e, 0xfc, 0xe1, 0x1b, 0x23, 0x14, 0xc3, 0xcf, 0xa1,
+	0x1c, 0xc1, 0x68, 0x65, 0x09, 0xd8, 0x8b, 0xc4, 0x75, 0xe4, 0x07, 0x2a, 0x9f, 0x69, 0x9d, 0x9f,
+	0x5b, 0x9d, 0x7e, 0x37, 0xb8, 0x14, 0x2a, 0x4a, 0x10, 0x9a, 0x6d, 0xe4, 0x99, 0x6b, 0x79, 0xc4,
+	0xdf, 0x0e, 0xd4, 0xe2, 0xf0, 0x6c, 0x8b, 0x88, 0xf1, 0xaf, 0x15, 0xd0, 0x68, 0x9f, 0x2d, 0x1a,
+	0xaa, 0xf4, 0xc0, 0x21, 0x77, 0xce, 0xca, 0xb5, 0x9d, 0x73, 0x61, 0x60, 0x6c, 0xc8, 0x6e, 0x60,
+	0x8a, 0xb9, 0x0d, 0xcc, 0x9f, 0x84, 0x28, 0x02, 0xf6, 0x2a, 0x45, 0xc9, 0x7b, 0xd7, 0xb2, 0x45,
+	0x2c, 0xe5, 0x8a, 0xb8, 0x02, 0xcb, 0x91, 0x84, 0x61, 0xb7, 0x18, 0x0a, 0x88, 0x9b, 0x50, 0x49,
+	0x20, 0x68, 0x5d, 0xec, 0x38, 0x26, 0x97, 0xb6, 0xac, 0xb3, 0xdf, 0xac, 0x65, 0x21, 0x7e, 0xc7,
+	0xb3, 0x5c, 0xd6, 0x55, 0x14, 0x44, 0xcb, 0x12, 0x83, 0xf0, 0x81, 0x64, 0x05, 0x89, 0x87, 0x88,
+	0xb4, 0xfb, 0x30, 0xc1, 0x65, 0x1f, 0x9c, 0x7e, 0x12, 0x5f, 0xe8, 0x21, 0x19, 0x7d, 0x8c, 0xb7,
+	0x5d, 0xd7, 0x63, 0xc1, 0x7b, 0xfd, 0x3b, 0x8a, 0x7f, 0x08, 0xcb, 0x8d, 0xae, 0x61, 0xf5, 0x58,
+	0x77, 0x95, 0x31, 0x7b, 0x66, 0x17, 0xb5, 0xec, 0x26, 0x0b, 0x7f, 0x00, 0x5a, 0xd6, 0x65, 0xf1,
+	0x93, 0xd8, 0xa1, 0xd8, 0xd4, 0x93, 0x28, 0xc3, 0xf0, 0xbf, 0x0a, 0x30, 0xb6, 0xe3, 0x19, 0xe7,
+	0xd7, 0xf1, 0x96, 0xaa, 0x42, 0x21, 0x59, 0x15, 0x5e, 0x41, 0xde, 0x0f, 0x19, 0xc8, 0xc2, 0x77,
+	0x6f, 0x3c, 0xef, 0xdd, 0x9b, 0x78, 0xf9, 0x21, 0x6d, 0x72, 0xa4, 0x21, 0x2d, 0x91, 0xff, 0xe5,
+	0x51, 0xf2, 0xff, 0x4b, 0x05, 0xaa, 0x2d, 0xe3, 0x09, 0x61, 0x76, 0x1e, 0xee, 0xea, 0xd7, 0xcc,
+	0xdc, 0xf8, 0x2f, 0x0a, 0x6b, 0x8b, 0x5e, 0x7f, 0xe9, 0xb1, 0x05, 0xb3, 0x34, 0xad, 0x99, 0xa4,
+	0x2f, 0x90, 0x53, 0x5f, 0x67, 0x65, 0xf1, 0x0c, 0x90, 0xcc, 0x4a, 0x64, 0xdc, 0x3d, 0x18, 0x37,
+	0x19, 0x44, 0x14, 0x8e, 0x78, 0xba, 0xe2, 0xd6, 0x13, 0xd8, 0xaf, 0xc5, 0xf9, 0xaf, 0x0a, 0x20,
+	0xde, 0x0b, 0xfc, 0x1f, 0x78, 0x64, 0x01, 0xe6, 0x12, 0xb2, 0x8a, 0xb9, 0xe3, 0x6f, 0x0a, 0xcc,
+	0x1d, 0xf5, 0xcf, 0xba, 0x96, 0xff, 0xe8, 0xf5, 0x57, 0xa2, 0xb6, 0x07, 0x13, 0x62, 0x6e, 0x41,
+	0x4b, 0x30, 0xa7, 0x37, 0xf7, 0x0f, 0x7f, 0xd4, 0xd4, 0x4f, 0x8f, 0x0f, 0x5a, 0x47, 0xcd, 0xc6,
+	0xde, 0xee, 0x5e, 0x73, 0xa7, 0xfa, 0x06, 0x02, 0x18, 0xdf, 0x3e, 0x6e, 0x7f, 0x74, 0xa8, 0x57,
+	0x15, 0x54, 0x81, 0xf2, 0xfe, 0xe1, 0x4e, 0x53, 0xdf, 0x6e, 0x1f, 0xea, 0xd5, 0x02, 0x45, 0xb5,
+	0x3e, 0x69, 0xb5, 0x9b, 0xfb, 0xd5, 0x62, 0xad, 0x01, 0x10, 0x0f, 0x4c, 0x68, 0x19, 0x16, 0xf4,
+	0xe6, 0x0f, 0x9a, 0x8d, 0xf6, 0xe9, 0xde, 0xee, 0xe9, 0x47, 0xdb, 0xad, 0x53, 0xbd, 0x79, 0xf4,
+	0xf1, 0x5e, 0xb3, 0x55, 0x7d, 0x03, 0x4d, 0xc1, 0x44, 0x63, 0xbb, 0xd5, 0xd8, 0xde, 0x69, 0xf2,
+	0x0b, 0xdb, 0x87, 0xfb, 0x1f, 0xb6, 0xda, 0x87, 0x07, 0xcd, 0x6a, 0x61, 0xf3, 0x8b, 0x25, 0x98,
+	0x08, 0x17, 0x65, 0x7f, 0x2c, 0xc0, 0xb4, 0xbc, 0xc3, 0x43, 0xab, 0x51, 0xc8, 0x65, 0x2c, 0x37,
+	0xb5, 0x9b, 0x39, 0x58, 0xe1, 0x97, 0xaf, 0x94, 0x9f, 0xff, 0xf3, 0xdf, 0xbf, 0x2b, 0xfc, 0x5d,
+	0x39, 0xb9, 0x8f, 0x36, 0xea, 0xd4, 0xc2, 0x7e, 0xfd, 0xb9, 0x30, 0xf4, 0x55, 0xb8, 0xa9, 0xf5,
+	0xeb, 0xcf, 0xe3, 0xc1, 0xe4, 0xaa, 0xee, 0x11, 0xb7, 0x6b, 0x11, 0xff, 0xe4, 0x01, 0xaa, 0xd7,
+	0x43, 0x93, 0xfa, 0xf5, 0xe7, 0xb2, 0xfd, 0xaf, 0xe2, 0xe3, 0x9e, 0x74, 0xcf, 0xc9, 0x2e, 0xda,
+	0x19, 0xf1, 0x93, 0x4c, 0xd6, 0x68, 0x39, 0x57, 0x54, 0xf4, 0x09, 0x40, 0xbc, 0x08, 0x42, 0x5a,
+	0xbc, 0xed, 0x48, 0x6f, 0x87, 0xb4, 0x9c, 0xe9, 0x05, 0x2f, 0x31, 0x93, 0xcc, 0xa2, 0x1b, 0x92,
+	0x10, 0x7d, 0xcb, 0xbc, 0x42, 0x7f, 0x56, 0xa0, 0x92, 0x58, 0x06, 0xa1, 0xd8, 0xba, 0x59, 0x4b,
+	0xa2, 0x5c, 0x0e, 0x27, 0x8c, 0x43, 0x7b, 0x4b, 0xa9, 0x9d, 0x7c, 0x7b, 0x4b, 0xa9, 0xe1, 0x51,
+	0xed, 0x88, 0xaf, 0xd1, 0xff, 0x0c, 0x2a, 0x89, 0x15, 0x8a, 0x24, 0x63, 0xd6, 0x6a, 0x25, 0x57,
+	0x46, 0x8d, 0xc9, 0x38, 0xbf, 0x99, 0xb6, 0xc2, 0x96, 0x52, 0x43, 0x0e, 0x54, 0x12, 0x9d, 0xbb,
+	0xc4, 0x23, 0x6b, 0x87, 0xa2, 0xdd, 0xca, 0x43, 0x8b, 0x28, 0xbc, 0xcd, 0x78, 0x2d, 0xd7, 0x96,
+	0x52, 0xbc, 0xea, 0x1d, 0x71, 0xbf, 0x07, 0x33, 0xc9, 0x85, 0x06, 0x92, 0xaf, 0xcc, 0x58, 0x8a,
+	0x68, 0xb7, 0x73, 0xf1, 0x49, 0x9e, 0x78, 0x80, 0xa7, 0xc7, 0xe9, 0xd1, 0x05, 0x54, 0x12, 0x13,
+	0x98, 0xa4, 0x64, 0xd6, 0x06, 0x45, 0xbb, 0x95, 0x87, 0x16, 0x0c, 0x45, 0x58, 0xd5, 0x06, 0xc2,
+	0xea, 0x53, 0x98, 0x0c, 0xf7, 0x0b, 0x48, 0x95, 0xe3, 0x55, 0xde, 0x4b, 0x68, 0xcb, 0x19, 0x18,
+	0x71, 0xf3, 0x4d, 0x76, 0xf3, 0x12, 0x5a, 0x48, 0xab, 0xc2, 0x56, 0x12, 0xe8, 0xa7, 0x30, 0x93,
+	0x9c, 0xfd, 0x25, 0xe3, 0x65, 0x2e, 0x05, 0xb4, 0xa5, 0x38, 0x64, 0x12, 0x1b, 0x00, 0x89, 0x13,
+	0x2d, 0xd3, 0x94, 0x0d, 0xaf, 0xd6, 0x57, 0x75, 0xd3, 0x08, 0x8c, 0xfb, 0x0a, 0x32, 0xa0, 0x1c,
+	0x8d, 0xe2, 0x28, 0x16, 0x39, 0x3d, 0xcc, 0x6b, 0x5a, 0x16, 0x2a, 0xa9, 0x4e, 0x2d, 0x9b, 0x09,
+	0xfa, 0x52, 0x81, 0x85, 0xcc, 0xd1, 0x18, 0xbd, 0x95, 0xed, 0x81, 0xd4, 0x58, 0xaf, 0xdd, 0x1b,
+	0x46, 0x26, 0xe4, 0x68, 0x33, 0x39, 0x0e, 0x6a, 0xf9, 0xc9, 0x76, 0xf2, 0xa0, 0x36, 0x6a, 0xea,
+	0xa2, 0x7f, 0xa4, 0x17, 0x8d, 0xa1, 0xf4, 0x77, 0xb3, 0x93, 0x24, 0x25, 0xfc, 0x5b, 0x43, 0xa8,
+	0x84, 0xec, 0x26, 0x93, 0xfd, 0xd3, 0xda, 0x9b, 0xf9, 0x35, 0x5d, 0xe4, 0xd6, 0xc9, 0xf7, 0x6a,
+	0xdf, 0x1d, 0xb5, 0x26, 0x87, 0x69, 0xf9, 0x63, 0x98, 0x96, 0x67, 0x6f, 0xe9, 0x29, 0xca, 0x18,
+	0xc9, 0x35, 0x14, 0x17, 0xcb, 0x10, 0x85, 0x17, 0x99, 0x9c, 0x55, 0x5a, 0x04, 0xa7, 0xea, 0xd1,
+	0xa2, 0xd8, 0x47, 0x01, 0xcc, 0x65, 0x0c, 0xd0, 0xe8, 0x4e, 0xe2, 0x35, 0xcb, 0x1e, 0xaf, 0x87,
+	0x3d, 0x79, 0xcb, 0x8c, 0xe5, 0x1c, 0x9a, 0xad, 0xbb, 0xfc, 0xfb, 0xb7, 0x23, 0xdf, 0x74, 0x61,
+	0x26, 0x39, 0xd5, 0x49, 0x99, 0x92, 0x39, 0xee, 0xe5, 0x56, 0x4f, 0xcc, 0x98, 0xac, 0x0e, 0x56,
+	0x17, 0x83, 0x5f, 0x43, 0xab, 0xa8, 0xd0, 0x31, 0x35, 0x99, 0xa7, 0x74, 0xcc, 0x9e, 0xdb, 0x5f,
+	0x5c, 0x47, 0xb1, 0x76, 0x8d, 0x75, 0x7c, 0x0a, 0x28, 0xba, 0x37, 0x9a, 0x84, 0x11, 0x1e, 0x64,
+	0x9a, 0x1e, 0xc5, 0xb5, 0x3b, 0xd7, 0xd2, 0x08, 0xce, 0x2a, 0xe3, 0x8c, 0x50, 0xb5, 0x2e, 0xfe,
+	0xd8, 0x79, 0x5b, 0x8c, 0xcc, 0xe8, 0x67, 0x0a, 0xa0, 0xc1, 0xd9, 0x55, 0xe2, 0x9c, 0x3b, 0x25,
+	0x6b, 0x77, 0xae, 0xa5, 0x11, 0x9c, 0xdf, 0x64, 0x9c, 0x57, 0xf0, 0xe2, 0x40, 0xd9, 0x60, 0xf3,
+	0x2f, 0xb5, 0xf8, 0x13, 0x28, 0x47, 0x63, 0x99, 0x54, 0x9d, 0xd2, 0xa3, 0x9a, 0x96, 0xea, 0xe2,
+	0xf1, 0xf7, 0xd9, 0xd5, 0xef, 0x69, 0xef, 0x0c, 0x56, 0x24, 0x8a, 0xbf, 0x36, 0x6b, 0x28, 0xdf,
+	0xcf, 0x58, 0x85, 0x17, 0x53, 0xb7, 0x9c, 0x23, 0xd7, 0x72, 0x7d, 0x9f, 0x71, 0x7d, 0x17, 0xbd,
+	0x0c, 0x57, 0x74, 0x0e, 0x10, 0x8f, 0x2b, 0x52, 0x1b, 0x34, 0x30, 0x2e, 0x69, 0x2b, 0x99, 0xb8,
+	0xe4, 0x2b, 0x89, 0x96, 0x72, 0x64, 0x40, 0xbf, 0x54, 0x60, 0x4a, 0x6a, 0xf8, 0xd1, 0x4a, 0xaa,
+	0xb8, 0x26, 0x34, 0x5c, 0xcd, 0x46, 0x0a, 0x5e, 0x42, 0xdf, 0xda, 0x4b, 0xe9, 0xfb, 0x1b, 0x05,
+	0xa6, 0xe5, 0x01, 0x43, 0xaa, 0x45, 0x19, 0x73, 0x47, 0x6e, 0xde, 0xee, 0x32, 0x19, 0x3e, 0xc0,
+	0xef, 0xbf, 0x84, 0x0c, 0x75, 0x97, 0x33, 0xda, 0x52, 0x6a, 0x67, 0xe3, 0x6c, 0x41, 0xf0, 0xce,
+	0xff, 0x06, 0x00, 0x60, 0x6e, 0xde, 0x92, 0xff, 0x20, 0x00, 0x00,
 }
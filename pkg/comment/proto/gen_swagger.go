@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+// gen_swagger embeds comment.swagger.json into comment.swagger.go
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+)
+
+func main() {
+	data, err := ioutil.ReadFile("comment.swagger.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src := fmt.Sprintf("// Code generated by gen_swagger.go. DO NOT EDIT.\n\npackage comment\n\n// SwaggerJSON is OpenAPI document of the HTTP gateway\nconst SwaggerJSON = %s\n", strconv.Quote(string(data)))
+	if err := ioutil.WriteFile("comment.swagger.go", []byte(src), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,3 @@
+package comment
+
+//go:generate go run gen_swagger.go
@@ -0,0 +1,155 @@
+package comment
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// sessionHeader is the metadata key carrying read-your-writes session token
+const sessionHeader = "x-comment-session"
+
+// writeMethods are RPCs after which caller is pinned to primary in read-your-writes mode
+var writeMethods = map[string]bool{
+	"CreateComment":         true,
+	"UpdateComment":         true,
+	"RemoveContent":         true,
+	"RestoreContent":        true,
+	"DeleteComment":         true,
+	"EraseUser":             true,
+	"DeleteCommentsForPost": true,
+	"RemoveContentForPost":  true,
+	"ApproveComment":        true,
+	"ClaimGuestComments":    true,
+	"SaveDraft":             true,
+	"DeleteDraft":           true,
+	"PublishDraft":          true,
+}
+
+type primaryKey struct{}
+
+// replica is a read-only database, it serves reads only while it answers pings
+type replica struct {
+	*sql.DB
+	name    string
+	healthy int32
+}
+
+func (r *replica) isHealthy() bool {
+	return atomic.LoadInt32(&r.healthy) == 1
+}
+
+// setHealthy updates replica state and logs its changes
+func (r *replica) setHealthy(healthy bool) {
+	var value int32
+	if healthy {
+		value = 1
+	}
+
+	if atomic.SwapInt32(&r.healthy, value) != value {
+		log.Printf("replica %s healthy: %v", r.name, healthy)
+	}
+}
+
+// reader returns database to run read query on: a healthy replica unless caller is pinned to primary
+func (db *db) reader(ctx context.Context) queryer {
+	if len(db.replicas) == 0 || usePrimary(ctx) {
+		return db.DB
+	}
+
+	start := atomic.AddUint32(&db.nextReplica, 1)
+	for i := range db.replicas {
+		r := db.replicas[(int(start)+i)%len(db.replicas)]
+		if r.isHealthy() {
+			return r.DB
+		}
+	}
+
+	return db.DB
+}
+
+// pingReplicas updates health of every replica
+func (db *db) pingReplicas(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, r := range db.replicas {
+		wg.Add(1)
+		go func(r *replica) {
+			defer wg.Done()
+			r.setHealthy(r.PingContext(ctx) == nil)
+		}(r)
+	}
+
+	wg.Wait()
+}
+
+// usePrimary reports whether reads must go to primary
+func usePrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// sessionToken returns token pinning caller to primary until window passes
+func sessionToken(window time.Duration) string {
+	return strconv.FormatInt(time.Now().Add(window).UnixNano(), 10)
+}
+
+// pinnedToPrimary reports whether session token in incoming metadata is fresh.
+// Tokens expiring later than a window from now are forged and ignored.
+func pinnedToPrimary(ctx context.Context, window time.Duration) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	values := md.Get(sessionHeader)
+	if len(values) == 0 {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return false
+	}
+
+	now := time.Now()
+	return expires > now.UnixNano() && expires <= now.Add(window).UnixNano()
+}
+
+// sessionUnaryInterceptor pins callers with fresh session token to primary
+// and issues session token after successful writes, it does nothing if read-your-writes mode is disabled
+func (c DBConfig) sessionUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if c.ReadYourWritesWindow <= 0 {
+			return handler(ctx, req)
+		}
+
+		if pinnedToPrimary(ctx, c.ReadYourWritesWindow) {
+			ctx = context.WithValue(ctx, primaryKey{}, true)
+		}
+
+		res, err := handler(ctx, req)
+		if err == nil && writeMethods[methodName(info.FullMethod)] {
+			grpc.SetHeader(ctx, metadata.Pairs(sessionHeader, sessionToken(c.ReadYourWritesWindow)))
+		}
+
+		return res, err
+	}
+}
+
+// sessionStreamInterceptor pins callers with fresh session token to primary
+func (c DBConfig) sessionStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if c.ReadYourWritesWindow > 0 && pinnedToPrimary(stream.Context(), c.ReadYourWritesWindow) {
+			stream = &serverStream{stream, context.WithValue(stream.Context(), primaryKey{}, true)}
+		}
+
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,91 @@
+package comment
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func sessionContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(sessionHeader, token))
+}
+
+func TestPinnedToPrimary(t *testing.T) {
+	window := time.Minute
+	cases := []struct {
+		ctx  context.Context
+		want bool
+	}{
+		{context.Background(), false},
+		{sessionContext(sessionToken(window)), true},
+		{sessionContext(strconv.FormatInt(time.Now().Add(-time.Second).UnixNano(), 10)), false},
+		{sessionContext(strconv.FormatInt(time.Now().Add(time.Hour).UnixNano(), 10)), false},
+		{sessionContext("garbage"), false},
+	}
+
+	for i, c := range cases {
+		if got := pinnedToPrimary(c.ctx, window); got != c.want {
+			t.Errorf("case %d: got %v want %v", i, got, c.want)
+		}
+	}
+}
+
+func TestSessionUnaryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/comment.Comment/GetComment"}
+	var primary bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		primary = usePrimary(ctx)
+		return nil, nil
+	}
+
+	ctx := sessionContext(sessionToken(time.Minute))
+	DBConfig{ReadYourWritesWindow: time.Minute}.sessionUnaryInterceptor()(ctx, nil, info, handler)
+	if !primary {
+		t.Errorf("caller with fresh session token is not pinned to primary")
+	}
+
+	DBConfig{}.sessionUnaryInterceptor()(ctx, nil, info, handler)
+	if primary {
+		t.Errorf("caller is pinned to primary with read-your-writes disabled")
+	}
+}
+
+func TestReader(t *testing.T) {
+	open := func() *sql.DB {
+		postgres, err := sql.Open("postgres", "postgres://localhost/comment")
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		return postgres
+	}
+
+	first, second := &replica{DB: open(), name: "#1"}, &replica{DB: open(), name: "#2"}
+	db := &db{DB: open(), replicas: []*replica{first, second}}
+	defer db.close()
+
+	if db.reader(context.Background()) != db.DB {
+		t.Errorf("read went to unhealthy replica")
+	}
+
+	second.setHealthy(true)
+	for i := 0; i < 2; i++ {
+		if db.reader(context.Background()) != second.DB {
+			t.Errorf("read did not go to healthy replica")
+		}
+	}
+
+	first.setHealthy(true)
+	if db.reader(context.Background()) == db.reader(context.Background()) {
+		t.Errorf("reads are not balanced between replicas")
+	}
+
+	if db.reader(context.WithValue(context.Background(), primaryKey{}, true)) != db.DB {
+		t.Errorf("pinned read went to replica")
+	}
+}
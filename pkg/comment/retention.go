@@ -0,0 +1,138 @@
+package comment
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+const retentionJobName = "retention"
+
+// RetentionConfig describes purge of removed comments and expired drafts, it applies to all tenants
+type RetentionConfig struct {
+	// Period is how long removed comments keep their bodies and revisions, zero keeps them forever
+	Period time.Duration
+	// Interval is time between purge runs, zero disables purge
+	Interval time.Duration
+	// BatchSize limits number of rows changed by a single statement
+	BatchSize int
+	// DryRun only counts rows purge would change
+	DryRun bool
+}
+
+// Validate checks that retention config is consistent
+func (c RetentionConfig) Validate() error {
+	if c.Period < 0 || c.Interval < 0 || c.BatchSize < 0 {
+		return errors.New("retention settings must not be negative")
+	}
+
+	if c.Interval > 0 && c.BatchSize == 0 {
+		return errors.New("retention batch size is required when purge is enabled")
+	}
+
+	return nil
+}
+
+// purgeStep is a part of purge, batch changes at most $2 rows older than $1 and count counts all of them
+type purgeStep struct {
+	action string
+	count  string
+	batch  string
+}
+
+// leafTombstones selects removed comments without replies
+const leafTombstones = "SELECT uid FROM comments c WHERE is_deleted=true AND removed_at<$1 AND NOT EXISTS (SELECT 1 FROM comments r WHERE r.parent_uid = c.uid)"
+
+// removedSteps purge removed comments by the time they were removed, later changes such as erasure of their author
+// don't postpone purge.
+// Deleting a leaf tombstone may turn its parent into one, next batches delete such parents too.
+var removedSteps = []purgeStep{
+	{
+		"delete_revisions",
+		"SELECT count(*) FROM comment_revisions r JOIN comments c ON c.uid = r.comment_uid WHERE c.is_deleted=true AND c.removed_at<$1",
+		"DELETE FROM comment_revisions WHERE uid IN (SELECT r.uid FROM comment_revisions r JOIN comments c ON c.uid = r.comment_uid WHERE c.is_deleted=true AND c.removed_at<$1 LIMIT $2)",
+	},
+	{
+		"delete_tombstones",
+		"SELECT count(*) FROM (" + leafTombstones + ") t",
+		"DELETE FROM comments WHERE uid IN (" + leafTombstones + " LIMIT $2)",
+	},
+	{
+		"clear_bodies",
+		"SELECT count(*) FROM comments WHERE is_deleted=true AND body<>'' AND removed_at<$1",
+		"UPDATE comments SET body='' WHERE uid IN (SELECT uid FROM comments WHERE is_deleted=true AND body<>'' AND removed_at<$1 LIMIT $2)",
+	},
+}
+
+var draftSteps = []purgeStep{
+	{
+		"delete_drafts",
+		"SELECT count(*) FROM comment_drafts WHERE modified_at<$1",
+		"DELETE FROM comment_drafts WHERE ctid IN (SELECT ctid FROM comment_drafts WHERE modified_at<$1 LIMIT $2)",
+	},
+}
+
+// purgeResult maps purge actions to numbers of rows changed, or which would be changed in dry run
+type purgeResult map[string]int64
+
+// purge runs steps for rows older than before in batches, in dry run rows are only counted.
+// Dry run counts only current leaf tombstones, not their parents which would become leaves.
+func (db *db) purge(ctx context.Context, steps []purgeStep, before time.Time, batchSize int, dryRun bool, result purgeResult) error {
+	for _, step := range steps {
+		var nRows int64
+		var err error
+		if dryRun {
+			err = queryRow(ctx, db, "purge.count."+step.action, []interface{}{&nRows}, step.count, before)
+		} else {
+			nRows, err = db.execBatches(ctx, "purge."+step.action, step.batch, before, batchSize)
+		}
+
+		result[step.action] += nRows
+		retentionRows.WithLabelValues(step.action, strconv.FormatBool(dryRun)).Add(float64(nRows))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newRetentionJob returns job purging removed comments after retention period and drafts after draft TTL.
+// Purge bypasses the datastore chain, so purged calls back after comments are purged to drop cached ones.
+// Caches of other replicas keep purged comments until cache TTL.
+func newRetentionJob(db *db, conf Config, purged func()) job {
+	return job{retentionJobName, conf.Retention.Interval, func(ctx context.Context) error {
+		now := time.Now()
+		result := make(purgeResult)
+		defer func() {
+			prefix := "retention purge"
+			if conf.Retention.DryRun {
+				prefix = "retention purge dry run, would change"
+			}
+
+			log.Printf("%s: %d revisions deleted, %d tombstones deleted, %d bodies cleared, %d drafts deleted", prefix,
+				result["delete_revisions"], result["delete_tombstones"], result["clear_bodies"], result["delete_drafts"])
+		}()
+
+		if conf.Retention.Period > 0 {
+			err := db.purge(ctx, removedSteps, now.Add(-conf.Retention.Period), conf.Retention.BatchSize, conf.Retention.DryRun, result)
+			// batches committed before an error are purged too
+			if !conf.Retention.DryRun {
+				purged()
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if conf.DraftTTL > 0 {
+			return db.purge(ctx, draftSteps, conf.draftsSince(now), conf.Retention.BatchSize, conf.Retention.DryRun, result)
+		}
+
+		return nil
+	}}
+}
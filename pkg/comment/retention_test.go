@@ -0,0 +1,164 @@
+package comment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+)
+
+func TestRetentionConfigValidate(t *testing.T) {
+	if err := (RetentionConfig{}).Validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if err := (RetentionConfig{Interval: time.Hour}).Validate(); err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+
+	if err := (RetentionConfig{Period: -time.Hour}).Validate(); err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}
+
+func TestJobLockID(t *testing.T) {
+	if jobLockID(retentionJobName) != jobLockID(retentionJobName) {
+		t.Errorf("expected stable lock ID")
+	}
+
+	if jobLockID(retentionJobName) == jobLockID("other") {
+		t.Errorf("expected different lock IDs of different jobs")
+	}
+}
+
+func TestRunLocked(t *testing.T) {
+	d := openTestDB(t)
+	defer d.close()
+
+	ctx := context.Background()
+	lockID := jobLockID(uuid.New().String())
+	ran, err := d.runLocked(ctx, lockID, func(ctx context.Context) error {
+		ran, err := d.runLocked(ctx, lockID, func(context.Context) error { return nil })
+		if err != nil || ran {
+			t.Errorf("expected job locked by another connection to be skipped, got %v, %v", ran, err)
+		}
+
+		return nil
+	})
+	if err != nil || !ran {
+		t.Fatalf("expected job to run, got %v, %v", ran, err)
+	}
+
+	ran, err = d.runLocked(ctx, lockID, func(context.Context) error { return nil })
+	if err != nil || !ran {
+		t.Errorf("expected released lock to be acquired again, got %v, %v", ran, err)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	d := openTestDB(t)
+	defer d.close()
+
+	ctx := withTenant(context.Background(), "retention-"+uuid.New().String())
+	target := postTarget(uuid.New())
+	userUID := uuid.New()
+	create := func(body string, parentUID uuid.UUID) *Comment {
+		comment, err := d.create(ctx, target, body, parentUID, userUID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		return comment
+	}
+
+	parent := create("parent", uuid.Nil)
+	reply := create("reply", parent.UID)
+	leaf := create("leaf", uuid.Nil)
+	fresh := create("fresh", uuid.Nil)
+	edited := "edited parent"
+	if _, err := d.update(ctx, parent.UID, commentChanges{body: &edited}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, comment := range []*Comment{parent, leaf, fresh} {
+		if err := d.removeContent(ctx, comment.UID, Removal{By: removedByAuthor}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	removedAt := time.Now().Add(-2 * time.Hour)
+	// modified_at is left as is, as erasure of author changes it
+	query := "UPDATE comments SET removed_at=$1 WHERE tenant=$2 AND uid IN ($3, $4)"
+	if _, err := d.ExecContext(ctx, query, removedAt, TenantFromContext(ctx), parent.UID.String(), leaf.UID.String()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	before := time.Now().Add(-time.Hour)
+	dryRun := make(purgeResult)
+	if err := d.purge(ctx, removedSteps, before, 10, true, dryRun); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if dryRun["delete_revisions"] < 1 || dryRun["delete_tombstones"] < 1 || dryRun["clear_bodies"] < 2 {
+		t.Errorf("unexpected dry run result %v", dryRun)
+	}
+
+	if _, err := d.getOne(ctx, leaf.UID); err != nil {
+		t.Errorf("expected dry run to keep comment, got %v", err)
+	}
+
+	cachePurged := false
+	conf := Config{Retention: RetentionConfig{Period: time.Hour, BatchSize: 10}}
+	if err := newRetentionJob(d, conf, func() { cachePurged = true }).run(ctx); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !cachePurged {
+		t.Errorf("expected cache to be purged")
+	}
+
+	if _, err := d.getOne(ctx, leaf.UID); err != errNotFound {
+		t.Errorf("expected leaf tombstone to be deleted, got %v", err)
+	}
+
+	purged, err := d.getOne(ctx, parent.UID)
+	if err != nil || purged.Body != "" || !purged.IsDeleted {
+		t.Errorf("expected removed parent with cleared body, got %+v, %v", purged, err)
+	}
+
+	if err := d.restoreContent(ctx, parent.UID); err != errNotFound {
+		t.Errorf("expected purged comment not to be restorable, got %v", err)
+	}
+
+	revisions, err := d.getRevisionsByUser(ctx, userUID)
+	if err != nil || len(revisions) != 0 {
+		t.Errorf("expected revisions to be deleted, got %v, %v", revisions, err)
+	}
+
+	for _, comment := range []*Comment{reply, fresh} {
+		kept, err := d.getOne(ctx, comment.UID)
+		if err != nil || kept.Body != comment.Body {
+			t.Errorf("expected comment to be kept, got %+v, %v", kept, err)
+		}
+	}
+
+	if _, err := d.saveDraft(ctx, userUID, target, uuid.Nil, "draft", time.Time{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	query = "UPDATE comment_drafts SET modified_at=$1 WHERE tenant=$2"
+	if _, err := d.ExecContext(ctx, query, removedAt, TenantFromContext(ctx)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := d.purge(ctx, draftSteps, before, 10, false, make(purgeResult)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err := d.getDraft(ctx, userUID, target, uuid.Nil, time.Time{}); err != errDraftNotFound {
+		t.Errorf("expected expired draft to be deleted, got %v", err)
+	}
+
+	d.deleteForTarget(ctx, target, 100)
+}
@@ -3,44 +3,160 @@ package comment
 import (
 	"fmt"
 	"net"
+	"net/http"
+	"sync"
 
 	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // Server implements comments service
 type Server struct {
-	db datastore
+	db     datastore
+	conf   Config
+	health *healthServer
+	jobs   *jobScheduler
+	stop   chan struct{}
+
+	mu       sync.Mutex
+	server   *grpc.Server
+	gateway  *http.Server
+	stopOnce sync.Once
 }
 
 // NewServer returns a new server
-func NewServer(connString string) (*Server, error) {
-	db, err := newDB(connString)
+func NewServer(conf Config) (*Server, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	db, err := newDB(conf.DB)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Server{db}, nil
+	if err := prometheus.Register(newDBStatsCollector(db.DB)); err != nil {
+		return nil, err
+	}
+
+	var ds datastore = instrumentedDatastore{db}
+	purged := func() {}
+	if conf.Cache.Size > 0 {
+		cached := newCachedDatastore(ds, conf.Cache)
+		ds, purged = cached, cached.cache.purge
+	}
+
+	var jobs *jobScheduler
+	if conf.Retention.Interval > 0 {
+		jobs = &jobScheduler{db, []job{newRetentionJob(db, conf, purged)}}
+	}
+
+	return &Server{db: ds, conf: conf, health: newHealthServer(), jobs: jobs, stop: make(chan struct{})}, nil
 }
 
 // Start starts a server
-func (s *Server) Start(port int, tracer opentracing.Tracer) error {
-	creds, err := credentials.NewServerTLSFromFile("/cert.pem", "/key.pem")
+func (s *Server) Start(tracer opentracing.Tracer) error {
+	creds, err := s.conf.TLS.transportCredentials()
 	if err != nil {
 		return err
 	}
 
-	server := grpc.NewServer(
-		grpc.Creds(creds),
-		grpc.UnaryInterceptor(otgrpc.OpenTracingServerInterceptor(tracer)),
-	)
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(chainUnaryServer(
+			otgrpc.OpenTracingServerInterceptor(tracer),
+			loggingUnaryInterceptor,
+			metricsUnaryInterceptor,
+			exceptHealthUnary(s.conf.Auth.unaryInterceptor()),
+			exceptHealthUnary(s.conf.Tenants.unaryInterceptor()),
+			s.conf.unaryInterceptor(),
+			s.conf.DB.sessionUnaryInterceptor(),
+		)),
+		grpc.StreamInterceptor(chainStreamServer(
+			otgrpc.OpenTracingStreamServerInterceptor(tracer),
+			loggingStreamInterceptor,
+			metricsStreamInterceptor,
+			exceptHealthStream(s.conf.Auth.streamInterceptor()),
+			exceptHealthStream(s.conf.Tenants.streamInterceptor()),
+			s.conf.streamInterceptor(),
+			s.conf.DB.sessionStreamInterceptor(),
+		)),
+	}
+	if s.conf.ConnectionTimeout > 0 {
+		opts = append(opts, grpc.ConnectionTimeout(s.conf.ConnectionTimeout))
+	}
+	if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	server := grpc.NewServer(opts...)
 	pb.RegisterCommentServer(server, s)
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	healthpb.RegisterHealthServer(server, s.health)
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.conf.Port))
 	if err != nil {
 		return err
 	}
+
+	s.mu.Lock()
+	select {
+	case <-s.stop:
+		s.mu.Unlock()
+		lis.Close()
+		return nil
+	default:
+		s.server = server
+	}
+	s.mu.Unlock()
+
+	go s.watchHealth(s.stop)
+	if s.jobs != nil {
+		s.jobs.start(s.stop)
+	}
+
 	return server.Serve(lis)
 }
+
+// Stop marks server as not serving, waits for in-flight gateway requests and RPCs to finish until ctx is done,
+// then closes remaining connections and the datastore
+func (s *Server) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		s.health.shutdown()
+		close(s.stop)
+	})
+
+	s.mu.Lock()
+	server, gateway := s.server, s.gateway
+	s.mu.Unlock()
+
+	if gateway != nil {
+		if err := gateway.Shutdown(ctx); err != nil {
+			gateway.Close()
+		}
+	}
+
+	if server != nil {
+		done := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			server.Stop()
+			<-done
+		}
+	}
+
+	return s.db.close()
+}
+
+// GracefulStop stops server waiting for all in-flight RPCs to finish
+func (s *Server) GracefulStop() error {
+	return s.Stop(context.Background())
+}
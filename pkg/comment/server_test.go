@@ -2,63 +2,93 @@ package comment
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	pb "github.com/andreymgn/RSOI-comment/pkg/comment/proto"
 	"github.com/google/uuid"
 	"golang.org/x/net/context"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 var (
 	errDummy     = errors.New("dummy")
 	dummyUID     = uuid.New()
+	userUID      = uuid.New()
 	nilUIDString = uuid.Nil.String()
 )
 
-type mockdb struct{}
+func postTarget(uid uuid.UUID) Target {
+	return Target{postType, uid.String()}
+}
+
+type mockdb struct {
+	down   bool
+	closed bool
+	drafts map[string]*Draft
+}
+
+func draftKey(userUID uuid.UUID, target Target, parentUID uuid.UUID) string {
+	return fmt.Sprint(userUID, target, parentUID)
+}
 
-func (mdb *mockdb) getAll(postUID uuid.UUID, parentUID uuid.UUID, pageNumber, pageSize int32) ([]*Comment, error) {
+func (mdb *mockdb) getAll(ctx context.Context, target Target, parentUID uuid.UUID, pageNumber, pageSize int32) ([]*Comment, error) {
 	result := make([]*Comment, 0)
 	uid1 := uuid.New()
 	uid2 := uuid.New()
 	uid3 := uuid.New()
 	pUID := uuid.New()
 
-	result = append(result, &Comment{uid1, uid2, pUID, "first comment body", uuid.Nil, time.Now(), time.Now(), false})
-	result = append(result, &Comment{uid2, uid3, pUID, "second comment body", uuid.Nil, time.Now(), time.Now(), false})
-	result = append(result, &Comment{uid3, uid1, pUID, "third comment body", uid1, time.Now(), time.Now(), false})
+	result = append(result, &Comment{uid1, uid2, postTarget(pUID), "first comment body", uuid.Nil, time.Now(), time.Now(), false, "", false, Removal{}})
+	result = append(result, &Comment{uid2, uid3, postTarget(pUID), "second comment body", uuid.Nil, time.Now(), time.Now(), false, "", false, Removal{}})
+	result = append(result, &Comment{uid3, uid1, postTarget(pUID), "third comment body", uid1, time.Now(), time.Now(), true, "", false, Removal{By: removedByModerator, Reason: "spam", At: time.Now()}})
 	return result, nil
 }
 
-func (mdb *mockdb) getOne(uid uuid.UUID) (*Comment, error) {
+func (mdb *mockdb) getOne(ctx context.Context, uid uuid.UUID) (*Comment, error) {
 	if uid == uuid.Nil {
 		uid := uuid.New()
 
-		return &Comment{uid, uid, uid, "first comment body", uuid.Nil, time.Now(), time.Now(), false}, nil
+		return &Comment{uid, uid, postTarget(uid), "first comment body", uuid.Nil, time.Now(), time.Now(), false, "", false, Removal{}}, nil
 	}
 
 	return nil, errDummy
 }
 
-func (mdb *mockdb) create(postUID uuid.UUID, body string, parentUID, userUID uuid.UUID) (*Comment, error) {
-	if postUID == uuid.Nil {
+func (mdb *mockdb) create(ctx context.Context, target Target, body string, parentUID, userUID uuid.UUID, guest *Guest) (*Comment, error) {
+	if target == postTarget(uuid.Nil) {
 		uid := uuid.New()
-		return &Comment{uid, userUID, postUID, "first comment body", uuid.Nil, time.Now(), time.Now(), false}, nil
+		comment := &Comment{uid, userUID, target, "first comment body", uuid.Nil, time.Now(), time.Now(), false, "", false, Removal{}}
+		if guest != nil {
+			comment.GuestName, comment.IsPending = guest.Name, guest.Pending
+		}
+
+		return comment, nil
 	}
 
 	return nil, errDummy
 }
 
-func (mdb *mockdb) update(uid uuid.UUID, body string) error {
+func (mdb *mockdb) update(ctx context.Context, uid uuid.UUID, changes commentChanges) (*Comment, error) {
 	if uid == uuid.Nil {
-		return nil
+		comment := &Comment{UID: uid, Body: "body"}
+		if changes.body != nil {
+			comment.Body = *changes.body
+		}
+
+		return comment, nil
 	}
 
-	return errDummy
+	return nil, errDummy
 }
 
-func (mdb *mockdb) removeContent(uid uuid.UUID) error {
+func (mdb *mockdb) removeContent(ctx context.Context, uid uuid.UUID, removal Removal) error {
 	if uid == uuid.Nil {
 		return nil
 	}
@@ -66,20 +96,179 @@ func (mdb *mockdb) removeContent(uid uuid.UUID) error {
 	return errDummy
 }
 
-func (mdb *mockdb) delete(uid uuid.UUID) error {
+func (mdb *mockdb) restoreContent(ctx context.Context, uid uuid.UUID) error {
 	if uid == uuid.Nil {
 		return nil
 	}
 
-	return errDummy
+	return errNotFound
 }
 
-func (mdb *mockdb) getOwner(uid uuid.UUID) (string, error) {
+func (mdb *mockdb) delete(ctx context.Context, uid uuid.UUID, mode deleteMode, removal Removal) (int64, error) {
+	switch {
+	case uid == uuid.Nil:
+		return 1, nil
+	case uid != dummyUID:
+		return 0, errDummy
+	case mode == deleteRejectIfReplies:
+		return 0, errHasReplies
+	case mode == deleteCascade:
+		return 2, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (mdb *mockdb) getOwner(ctx context.Context, uid uuid.UUID) (string, error) {
 	return nilUIDString, nil
 }
 
+func (mdb *mockdb) getAllByUser(ctx context.Context, uid uuid.UUID) ([]*Comment, error) {
+	if uid == userUID {
+		return []*Comment{
+			{uuid.New(), userUID, postTarget(uuid.New()), "first comment body", uuid.Nil, time.Now(), time.Now(), false, "", false, Removal{}},
+			{uuid.New(), userUID, postTarget(uuid.New()), "second comment body", uuid.Nil, time.Now(), time.Now(), true, "", false, Removal{By: removedByAuthor}},
+			{uuid.New(), userUID, postTarget(uuid.New()), "third comment body", uuid.Nil, time.Now(), time.Now(), true, "", false, Removal{By: removedByModerator, Reason: "spam"}},
+		}, nil
+	}
+
+	return nil, errDummy
+}
+
+func (mdb *mockdb) getRevisionsByUser(ctx context.Context, userUID uuid.UUID) ([]*Revision, error) {
+	return make([]*Revision, 0), nil
+}
+
+func (mdb *mockdb) eraseUser(ctx context.Context, uid uuid.UUID) (int64, error) {
+	if uid == userUID {
+		return 1, nil
+	}
+
+	return 0, errDummy
+}
+
+func (mdb *mockdb) deleteForTarget(ctx context.Context, target Target, batchSize int32) (int64, error) {
+	if target == postTarget(uuid.Nil) {
+		return 3, nil
+	}
+
+	return 0, errDummy
+}
+
+func (mdb *mockdb) removeContentForTarget(ctx context.Context, target Target, batchSize int32) (int64, error) {
+	if target == postTarget(uuid.Nil) {
+		return 3, nil
+	}
+
+	return 0, errDummy
+}
+
+func (mdb *mockdb) getPending(ctx context.Context, pageSize, pageNumber int32) ([]*Comment, error) {
+	uid := uuid.New()
+	return []*Comment{{UID: uid, Target: postTarget(uid), Body: "pending comment body", GuestName: "guest", IsPending: true}}, nil
+}
+
+func (mdb *mockdb) getRemoved(ctx context.Context, reason string, pageSize, pageNumber int32) ([]*Comment, error) {
+	uid := uuid.New()
+	removal := Removal{By: removedByModerator, Reason: "spam", Notes: "links to a shop", At: time.Now()}
+	comments := []*Comment{{UID: uid, Target: postTarget(uid), Body: "removed comment body", IsDeleted: true, Removal: removal}}
+	if reason != "" && reason != removal.Reason {
+		return nil, nil
+	}
+
+	return comments, nil
+}
+
+func (mdb *mockdb) approve(ctx context.Context, uid uuid.UUID) (*Comment, error) {
+	if uid == uuid.Nil {
+		return &Comment{UID: uid, Target: postTarget(uid), Body: "body", GuestName: "guest"}, nil
+	}
+
+	return nil, errNotFound
+}
+
+func (mdb *mockdb) claimGuest(ctx context.Context, emailHash string, userUID uuid.UUID) (int64, error) {
+	if userUID == uuid.Nil {
+		return 0, errDummy
+	}
+
+	return 2, nil
+}
+
+func (mdb *mockdb) importComments(ctx context.Context, comments []*Comment) (int64, error) {
+	return int64(len(comments)), nil
+}
+
+func (mdb *mockdb) saveDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID, body string, notBefore time.Time) (*Draft, error) {
+	if mdb.drafts == nil {
+		mdb.drafts = make(map[string]*Draft)
+	}
+
+	now := time.Now()
+	draft := &Draft{userUID, target, parentUID, body, now, now}
+	mdb.drafts[draftKey(userUID, target, parentUID)] = draft
+	return draft, nil
+}
+
+func (mdb *mockdb) getDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID, notBefore time.Time) (*Draft, error) {
+	draft, ok := mdb.drafts[draftKey(userUID, target, parentUID)]
+	if !ok || draft.ModifiedAt.Before(notBefore) {
+		return nil, errDraftNotFound
+	}
+
+	return draft, nil
+}
+
+func (mdb *mockdb) getDrafts(ctx context.Context, userUID uuid.UUID, notBefore time.Time, pageSize, pageNumber int32) ([]*Draft, error) {
+	result := make([]*Draft, 0)
+	for _, draft := range mdb.drafts {
+		if draft.UserUID == userUID && !draft.ModifiedAt.Before(notBefore) {
+			result = append(result, draft)
+		}
+	}
+
+	return result, nil
+}
+
+func (mdb *mockdb) deleteDraft(ctx context.Context, userUID uuid.UUID, target Target, parentUID uuid.UUID) error {
+	key := draftKey(userUID, target, parentUID)
+	if _, ok := mdb.drafts[key]; !ok {
+		return errDraftNotFound
+	}
+
+	delete(mdb.drafts, key)
+	return nil
+}
+
+func (mdb *mockdb) ping(ctx context.Context) error {
+	if mdb.down {
+		return errDummy
+	}
+
+	return nil
+}
+
+func (mdb *mockdb) close() error {
+	mdb.closed = true
+	return nil
+}
+
+type mockExportStream struct {
+	grpc.ServerStream
+	records []*pb.UserDataRecord
+}
+
+func (m *mockExportStream) Send(record *pb.UserDataRecord) error {
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *mockExportStream) Context() context.Context {
+	return context.Background()
+}
+
 func TestListComments(t *testing.T) {
-	s := &Server{&mockdb{}}
+	s := &Server{db: &mockdb{}}
 	var pageSize int32 = 3
 	req := &pb.ListCommentsRequest{PostUid: nilUIDString, PageSize: pageSize}
 	res, err := s.ListComments(context.Background(), req)
@@ -93,7 +282,7 @@ func TestListComments(t *testing.T) {
 }
 
 func TestGetComment(t *testing.T) {
-	s := &Server{&mockdb{}}
+	s := &Server{db: &mockdb{}}
 	req := &pb.GetCommentRequest{Uid: nilUIDString}
 	_, err := s.GetComment(context.Background(), req)
 	if err != nil {
@@ -102,7 +291,7 @@ func TestGetComment(t *testing.T) {
 }
 
 func TestGetCommentFail(t *testing.T) {
-	s := &Server{&mockdb{}}
+	s := &Server{db: &mockdb{}}
 	req := &pb.GetCommentRequest{Uid: ""}
 	_, err := s.GetComment(context.Background(), req)
 	if err == nil {
@@ -111,16 +300,23 @@ func TestGetCommentFail(t *testing.T) {
 }
 
 func TestCreateComment(t *testing.T) {
-	s := &Server{&mockdb{}}
-	req := &pb.CreateCommentRequest{PostUid: nilUIDString, UserUid: nilUIDString}
+	s := &Server{db: &mockdb{}}
+	req := &pb.CreateCommentRequest{PostUid: nilUIDString, UserUid: userUID.String()}
 	_, err := s.CreateComment(context.Background(), req)
 	if err != nil {
 		t.Errorf("unexpected error %v", err)
 	}
+
+	// nil user UID marks guest and erased authors' comments
+	req.UserUid = nilUIDString
+	_, err = s.CreateComment(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unexpected error: got %v want %v", err, codes.InvalidArgument)
+	}
 }
 
 func TestCreateCommentFail(t *testing.T) {
-	s := &Server{&mockdb{}}
+	s := &Server{db: &mockdb{}}
 
 	req := &pb.CreateCommentRequest{}
 	_, err := s.CreateComment(context.Background(), req)
@@ -130,7 +326,7 @@ func TestCreateCommentFail(t *testing.T) {
 }
 
 func TestUpdateComment(t *testing.T) {
-	s := &Server{&mockdb{}}
+	s := &Server{db: &mockdb{}}
 	req := &pb.UpdateCommentRequest{Uid: nilUIDString}
 	_, err := s.UpdateComment(context.Background(), req)
 	if err != nil {
@@ -138,8 +334,27 @@ func TestUpdateComment(t *testing.T) {
 	}
 }
 
+func TestUpdateCommentMask(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.UpdateCommentRequest{Uid: nilUIDString, Body: "new body", UpdateMask: &field_mask.FieldMask{Paths: []string{"body"}}}
+	res, err := s.UpdateComment(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if res.Body != req.Body {
+		t.Errorf("unexpected body: got %q want %q", res.Body, req.Body)
+	}
+
+	req.UpdateMask.Paths = []string{"body", "userUid"}
+	_, err = s.UpdateComment(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
 func TestUpdateCommentFail(t *testing.T) {
-	s := &Server{&mockdb{}}
+	s := &Server{db: &mockdb{}}
 
 	req := &pb.UpdateCommentRequest{}
 	_, err := s.UpdateComment(context.Background(), req)
@@ -149,7 +364,7 @@ func TestUpdateCommentFail(t *testing.T) {
 }
 
 func TestDeleteComment(t *testing.T) {
-	s := &Server{&mockdb{}}
+	s := &Server{db: &mockdb{}}
 	req := &pb.DeleteCommentRequest{Uid: nilUIDString}
 	_, err := s.DeleteComment(context.Background(), req)
 	if err != nil {
@@ -158,11 +373,349 @@ func TestDeleteComment(t *testing.T) {
 }
 
 func TestDeleteCommentFail(t *testing.T) {
-	s := &Server{&mockdb{}}
+	s := &Server{db: &mockdb{}}
 
 	req := &pb.DeleteCommentRequest{}
 	_, err := s.DeleteComment(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestDeleteCommentModes(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+
+	req := &pb.DeleteCommentRequest{Uid: dummyUID.String(), Mode: pb.DeleteMode_REJECT_IF_HAS_REPLIES}
+	_, err := s.DeleteComment(context.Background(), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("unexpected error: got %v want %v", err, errHasReplies)
+	}
+
+	req.Mode = pb.DeleteMode_CASCADE
+	res, err := s.DeleteComment(context.Background(), req)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if res.DeletedCount != 2 || res.Tombstoned {
+		t.Errorf("unexpected response %v", res)
+	}
+
+	req.Mode = pb.DeleteMode_TOMBSTONE
+	res, err = s.DeleteComment(context.Background(), req)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if !res.Tombstoned {
+		t.Errorf("expected comment to be tombstoned")
+	}
+
+	req.Mode = pb.DeleteMode(42)
+	_, err = s.DeleteComment(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unexpected error: got %v want %v", err, errBadMode)
+	}
+}
+
+func TestExportUserData(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.ExportUserDataRequest{UserUid: userUID.String()}
+	stream := new(mockExportStream)
+	err := s.ExportUserData(req, stream)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if len(stream.records) != 3 {
+		t.Fatalf("unexpected number of records: got %v want %v", len(stream.records), 3)
+	}
+
+	if stream.records[1].Comment.Body != "second comment body" {
+		t.Errorf("expected body of comment removed by user, got %v", stream.records[1].Comment)
+	}
+
+	removed := stream.records[2].Comment
+	if removed.Body != "third comment body" || removed.RemovedBy != pb.Remover_MODERATOR || removed.RemovalReason != "spam" {
+		t.Errorf("expected body and removal of comment removed by moderator, got %v", removed)
+	}
+}
+
+func TestExportUserDataFail(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.ExportUserDataRequest{UserUid: dummyUID.String()}
+	err := s.ExportUserData(req, new(mockExportStream))
+	if err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+
+	req.UserUid = nilUIDString
+	err = s.ExportUserData(req, new(mockExportStream))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unexpected error: got %v want %v", err, codes.InvalidArgument)
+	}
+}
+
+func TestEraseUser(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.EraseUserRequest{UserUid: userUID.String()}
+	res, err := s.EraseUser(context.Background(), req)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if res.ErasedCount != 1 {
+		t.Errorf("unexpected erased count: got %v want %v", res.ErasedCount, 1)
+	}
+}
+
+func TestEraseUserFail(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.EraseUserRequest{}
+	_, err := s.EraseUser(context.Background(), req)
+	if err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+
+	for _, uid := range []uuid.UUID{uuid.Nil, erasedUserUID} {
+		req.UserUid = uid.String()
+		_, err = s.EraseUser(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("unexpected error: got %v want %v", err, codes.InvalidArgument)
+		}
+	}
+}
+
+func TestDeleteCommentsForPost(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.DeleteCommentsForPostRequest{PostUid: nilUIDString}
+	res, err := s.DeleteCommentsForPost(context.Background(), req)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if res.AffectedCount != 3 {
+		t.Errorf("unexpected affected count: got %v want %v", res.AffectedCount, 3)
+	}
+}
+
+func TestDeleteCommentsForPostFail(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.DeleteCommentsForPostRequest{PostUid: dummyUID.String()}
+	_, err := s.DeleteCommentsForPost(context.Background(), req)
 	if err == nil {
 		t.Errorf("expected error, got nothing")
 	}
 }
+
+func TestRestoreContent(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.RestoreContentRequest{Uid: nilUIDString}
+	_, err := s.RestoreContent(context.Background(), req)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestRestoreContentFail(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.RestoreContentRequest{Uid: dummyUID.String()}
+	_, err := s.RestoreContent(context.Background(), req)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unexpected error: got %v want %v", err, errNotFound)
+	}
+}
+
+func TestRemoveContentForPost(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.RemoveContentForPostRequest{PostUid: nilUIDString}
+	res, err := s.RemoveContentForPost(context.Background(), req)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if res.AffectedCount != 3 {
+		t.Errorf("unexpected affected count: got %v want %v", res.AffectedCount, 3)
+	}
+}
+
+func TestRemoveContentForPostFail(t *testing.T) {
+	s := &Server{db: &mockdb{}}
+	req := &pb.RemoveContentForPostRequest{}
+	_, err := s.RemoveContentForPost(context.Background(), req)
+	if err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}
+
+func TestBatchSize(t *testing.T) {
+	cases := []struct {
+		requested, want int32
+	}{
+		{0, defaultBatchSize},
+		{-5, defaultBatchSize},
+		{50, 50},
+		{maxBatchSize + 1, maxBatchSize},
+	}
+
+	for _, c := range cases {
+		if got := batchSize(c.requested); got != c.want {
+			t.Errorf("batchSize(%v): got %v want %v", c.requested, got, c.want)
+		}
+	}
+}
+
+func TestUpdateHealth(t *testing.T) {
+	mdb := &mockdb{}
+	s := &Server{db: mdb, health: newHealthServer()}
+	if status := s.updateHealth(); status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("unexpected status: got %v want %v", status, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	mdb.down = true
+	if status := s.updateHealth(); status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("unexpected status: got %v want %v", status, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	res, err := s.health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: healthServiceName})
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if res.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("unexpected status: got %v want %v", res.Status, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+func TestWatchHealthStop(t *testing.T) {
+	s := &Server{db: &mockdb{}, health: newHealthServer()}
+	stop := make(chan struct{})
+	close(stop)
+	s.watchHealth(stop)
+
+	res, err := s.health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if res.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("unexpected status: got %v want %v", res.Status, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+func TestStop(t *testing.T) {
+	mdb := &mockdb{}
+	s := &Server{db: mdb, health: newHealthServer(), stop: make(chan struct{})}
+	s.health.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+
+	err := s.GracefulStop()
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if !mdb.closed {
+		t.Errorf("datastore not closed")
+	}
+
+	s.updateHealth()
+	res, err := s.health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if res.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("unexpected status after stop: got %v want %v", res.Status, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+func TestTargetOf(t *testing.T) {
+	postUID := uuid.New()
+	cases := []struct {
+		postUID, resourceType, resourceID string
+		want                              Target
+		ok                                bool
+	}{
+		{postUID.String(), "", "", postTarget(postUID), true},
+		{"", "post", strings.ToUpper(postUID.String()), postTarget(postUID), true},
+		{postUID.String(), "post", postUID.String(), postTarget(postUID), true},
+		{"", "photo", "42", Target{"photo", "42"}, true},
+		{postUID.String(), "photo", "42", Target{}, false},
+		{"", "post", "42", Target{}, false},
+		{"", "Photo", "42", Target{}, false},
+		{"", "photo", "", Target{}, false},
+		{"", "", "", Target{}, false},
+	}
+
+	for _, c := range cases {
+		got, err := targetOf(c.postUID, c.resourceType, c.resourceID)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("targetOf(%q, %q, %q): got %v, %v want %v", c.postUID, c.resourceType, c.resourceID, got, err, c.want)
+		}
+
+		if err != nil && status.Code(err) != codes.InvalidArgument {
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+}
+
+func TestDrafts(t *testing.T) {
+	s := &Server{db: &mockdb{}, conf: Config{DraftTTL: time.Hour, Limits: Limits{MaxBodyLength: 10}}}
+	ctx := context.Background()
+	userUID := uuid.New().String()
+
+	save := &pb.SaveDraftRequest{UserUid: userUID, PostUid: nilUIDString, Body: "draft"}
+	saved, err := s.SaveDraft(ctx, save)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if saved.Body != "draft" || saved.ExpiresAt == nil || saved.ResourceType != postType {
+		t.Errorf("unexpected draft %v", saved)
+	}
+
+	save.Body = strings.Repeat("x", 11)
+	if _, err := s.SaveDraft(ctx, save); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	get := &pb.GetDraftRequest{UserUid: userUID, ResourceType: postType, ResourceId: nilUIDString}
+	if draft, err := s.GetDraft(ctx, get); err != nil || draft.Body != "draft" {
+		t.Errorf("expected saved draft, got %v, %v", draft, err)
+	}
+
+	list, err := s.ListDrafts(ctx, &pb.ListDraftsRequest{UserUid: userUID})
+	if err != nil || len(list.Drafts) != 1 {
+		t.Errorf("expected 1 draft, got %v, %v", list, err)
+	}
+
+	publish := &pb.PublishDraftRequest{UserUid: userUID, PostUid: nilUIDString}
+	comment, err := s.PublishDraft(ctx, publish)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if comment.UserUid != userUID {
+		t.Errorf("unexpected comment %v", comment)
+	}
+
+	if _, err := s.GetDraft(ctx, get); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound after publishing, got %v", err)
+	}
+
+	if _, err := s.PublishDraft(ctx, publish); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	delete := &pb.DeleteDraftRequest{UserUid: userUID, PostUid: nilUIDString}
+	if _, err := s.DeleteDraft(ctx, delete); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestPublishDraftValidation(t *testing.T) {
+	mdb := &mockdb{}
+	s := &Server{db: mdb, conf: Config{Limits: Limits{MaxBodyLength: 10}}}
+	ctx := context.Background()
+	userUID := uuid.New()
+
+	// draft saved before the limit was lowered must still pass CreateComment validation
+	mdb.saveDraft(ctx, userUID, postTarget(uuid.Nil), uuid.Nil, strings.Repeat("x", 11), time.Time{})
+	_, err := s.PublishDraft(ctx, &pb.PublishDraftRequest{UserUid: userUID.String(), PostUid: nilUIDString})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	if _, err := mdb.getDraft(ctx, userUID, postTarget(uuid.Nil), uuid.Nil, time.Time{}); err != nil {
+		t.Errorf("expected draft to be kept, got %v", err)
+	}
+}
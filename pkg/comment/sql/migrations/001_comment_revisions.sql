@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS comment_revisions (
+    uid UUID PRIMARY KEY,
+    comment_uid UUID NOT NULL REFERENCES comments(uid) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS comments_user_uid_idx ON comments (user_uid);
+CREATE INDEX IF NOT EXISTS comment_revisions_comment_uid_idx ON comment_revisions (comment_uid);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS comments_post_uid_idx ON comments (post_uid, parent_uid);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS comments_parent_uid_idx ON comments (parent_uid);
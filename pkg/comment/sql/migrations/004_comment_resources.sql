@@ -0,0 +1,14 @@
+-- comments of posts become comments of resources of type 'post'
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'comments' AND column_name = 'post_uid') THEN
+        ALTER TABLE comments RENAME COLUMN post_uid TO resource_id;
+        ALTER TABLE comments ALTER COLUMN resource_id TYPE TEXT USING resource_id::text;
+    END IF;
+END $$;
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS resource_type TEXT NOT NULL DEFAULT 'post';
+ALTER TABLE comments ALTER COLUMN resource_type DROP DEFAULT;
+
+DROP INDEX IF EXISTS comments_post_uid_idx;
+CREATE INDEX IF NOT EXISTS comments_resource_idx ON comments (resource_type, resource_id, parent_uid);
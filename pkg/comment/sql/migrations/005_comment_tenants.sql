@@ -0,0 +1,8 @@
+-- comments created before tenants were introduced belong to tenant 'default'
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE comments ALTER COLUMN tenant DROP DEFAULT;
+
+DROP INDEX IF EXISTS comments_user_uid_idx;
+CREATE INDEX comments_user_uid_idx ON comments (tenant, user_uid);
+DROP INDEX IF EXISTS comments_resource_idx;
+CREATE INDEX comments_resource_idx ON comments (tenant, resource_type, resource_id, parent_uid);
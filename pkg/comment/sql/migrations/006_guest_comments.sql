@@ -0,0 +1,9 @@
+-- guest comments have nil user_uid and are told apart from comments of erased users by guest_name
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS guest_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS guest_email_hash TEXT;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS challenge_uid UUID;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS is_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS comments_challenge_uid_idx ON comments (challenge_uid);
+CREATE INDEX IF NOT EXISTS comments_guest_email_hash_idx ON comments (tenant, guest_email_hash) WHERE guest_email_hash IS NOT NULL;
+CREATE INDEX IF NOT EXISTS comments_pending_idx ON comments (tenant, created_at) WHERE is_pending;
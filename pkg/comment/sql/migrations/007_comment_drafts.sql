@@ -0,0 +1,14 @@
+-- parent_uid of drafts of top level comments is nil UUID, so it can be part of primary key
+CREATE TABLE IF NOT EXISTS comment_drafts (
+    tenant TEXT NOT NULL,
+    user_uid UUID NOT NULL,
+    resource_type TEXT NOT NULL,
+    resource_id TEXT NOT NULL,
+    parent_uid UUID NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    modified_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant, user_uid, resource_type, resource_id, parent_uid)
+);
+
+CREATE INDEX IF NOT EXISTS comment_drafts_modified_at_idx ON comment_drafts (modified_at);
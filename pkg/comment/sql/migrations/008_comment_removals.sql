@@ -0,0 +1,3 @@
+-- removed_by is 'author' or 'moderator', empty for comments removed before it was recorded or by bulk operations
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS removed_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS removal_reason TEXT NOT NULL DEFAULT '';
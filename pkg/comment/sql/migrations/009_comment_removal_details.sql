@@ -0,0 +1,7 @@
+-- removed_at of comments removed before it was recorded is unknown, their modified_at is the best guess
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS removal_notes TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS removed_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE comments SET removed_at=modified_at WHERE is_deleted AND removed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS comments_removed_idx ON comments (tenant, removed_at) WHERE is_deleted;
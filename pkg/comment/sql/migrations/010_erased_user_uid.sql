@@ -0,0 +1,6 @@
+-- comments of erased users had nil user UID, which guest comments have too, guest comments always have guest_name
+UPDATE comments SET user_uid='ffffffff-ffff-ffff-ffff-ffffffffffff'
+    WHERE is_deleted AND user_uid='00000000-0000-0000-0000-000000000000' AND guest_name='';
+
+UPDATE comments SET removed_by='system', removal_reason='user_erased'
+    WHERE is_deleted AND removed_by='' AND user_uid='ffffffff-ffff-ffff-ffff-ffffffffffff';
@@ -2,11 +2,50 @@ CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
 CREATE TABLE comments (
     uid UUID PRIMARY KEY,
+    tenant TEXT NOT NULL,
     user_uid UUID NOT NULL,
-    post_uid UUID NOT NULL,
+    resource_type TEXT NOT NULL,
+    resource_id TEXT NOT NULL,
     body TEXT NOT NULL,
     parent_uid UUID,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL,
     modified_at TIMESTAMP WITH TIME ZONE NOT NULL,
-    is_deleted BOOLEAN NOT NULL DEFAULT FALSE
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    guest_name TEXT NOT NULL DEFAULT '',
+    guest_email_hash TEXT,
+    challenge_uid UUID,
+    is_pending BOOLEAN NOT NULL DEFAULT FALSE,
+    removed_by TEXT NOT NULL DEFAULT '',
+    removal_reason TEXT NOT NULL DEFAULT '',
+    removal_notes TEXT NOT NULL DEFAULT '',
+    removed_at TIMESTAMP WITH TIME ZONE
 );
+
+CREATE TABLE comment_revisions (
+    uid UUID PRIMARY KEY,
+    comment_uid UUID NOT NULL REFERENCES comments(uid) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE comment_drafts (
+    tenant TEXT NOT NULL,
+    user_uid UUID NOT NULL,
+    resource_type TEXT NOT NULL,
+    resource_id TEXT NOT NULL,
+    parent_uid UUID NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    modified_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant, user_uid, resource_type, resource_id, parent_uid)
+);
+
+CREATE INDEX comments_user_uid_idx ON comments (tenant, user_uid);
+CREATE INDEX comment_revisions_comment_uid_idx ON comment_revisions (comment_uid);
+CREATE INDEX comments_resource_idx ON comments (tenant, resource_type, resource_id, parent_uid);
+CREATE INDEX comments_parent_uid_idx ON comments (parent_uid);
+CREATE UNIQUE INDEX comments_challenge_uid_idx ON comments (challenge_uid);
+CREATE INDEX comments_guest_email_hash_idx ON comments (tenant, guest_email_hash) WHERE guest_email_hash IS NOT NULL;
+CREATE INDEX comments_pending_idx ON comments (tenant, created_at) WHERE is_pending;
+CREATE INDEX comments_removed_idx ON comments (tenant, removed_at) WHERE is_deleted;
+CREATE INDEX comment_drafts_modified_at_idx ON comment_drafts (modified_at);
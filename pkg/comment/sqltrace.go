@@ -0,0 +1,105 @@
+package comment
+
+import (
+	"database/sql"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"golang.org/x/net/context"
+)
+
+const (
+	queryNameTag = "db.query_name"
+	rowsTag      = "db.rows"
+)
+
+// queryer is implemented by *sql.DB and *sql.Tx
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// startSQLSpan starts child span of SQL statement.
+// Span is started by tracer of the parent span, so it doesn't depend on the global tracer.
+func startSQLSpan(ctx context.Context, name, query string) (opentracing.Span, context.Context) {
+	tracer := opentracing.GlobalTracer()
+	var opts []opentracing.StartSpanOption
+	if parent := opentracing.SpanFromContext(ctx); parent != nil {
+		tracer = parent.Tracer()
+		opts = append(opts, opentracing.ChildOf(parent.Context()))
+	}
+
+	span := tracer.StartSpan("sql."+name, opts...)
+	ext.SpanKindRPCClient.Set(span)
+	ext.DBType.Set(span, "sql")
+	ext.DBStatement.Set(span, query)
+	span.SetTag(queryNameTag, name)
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
+
+// finishSQLSpan tags span with number of rows or error and finishes it
+func finishSQLSpan(span opentracing.Span, rows int64, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		ext.Error.Set(span, true)
+		span.LogFields(otlog.Error(err))
+	} else {
+		span.SetTag(rowsTag, rows)
+	}
+
+	span.Finish()
+}
+
+// execContext runs statement and returns number of affected rows.
+// Like the other helpers it translates database errors to domain errors.
+func execContext(ctx context.Context, q queryer, name, query string, args ...interface{}) (int64, error) {
+	span, ctx := startSQLSpan(ctx, name, query)
+	var nRows int64
+	result, err := q.ExecContext(ctx, query, args...)
+	if err == nil {
+		nRows, err = result.RowsAffected()
+	}
+
+	finishSQLSpan(span, nRows, err)
+	return nRows, fromPostgres(err)
+}
+
+// queryRow runs query returning at most one row and scans it into dest
+func queryRow(ctx context.Context, q queryer, name string, dest []interface{}, query string, args ...interface{}) error {
+	span, ctx := startSQLSpan(ctx, name, query)
+	err := q.QueryRowContext(ctx, query, args...).Scan(dest...)
+	var nRows int64
+	if err == nil {
+		nRows = 1
+	}
+
+	finishSQLSpan(span, nRows, err)
+	return fromPostgres(err)
+}
+
+// queryRows runs query and calls scan for every returned row
+func queryRows(ctx context.Context, q queryer, name string, scan func(*sql.Rows) error, query string, args ...interface{}) error {
+	span, ctx := startSQLSpan(ctx, name, query)
+	var nRows int64
+	err := func() error {
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+		for rows.Next() {
+			if err := scan(rows); err != nil {
+				return err
+			}
+
+			nRows++
+		}
+
+		return rows.Err()
+	}()
+
+	finishSQLSpan(span, nRows, err)
+	return fromPostgres(err)
+}
@@ -0,0 +1,66 @@
+package comment
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"golang.org/x/net/context"
+)
+
+// mockQueryer affects three rows with every statement and fails every query
+type mockQueryer struct{}
+
+func (mockQueryer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return driver.RowsAffected(3), nil
+}
+
+func (mockQueryer) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errDummy
+}
+
+func (mockQueryer) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func TestExecContextSpan(t *testing.T) {
+	tracer := mocktracer.New()
+	parent := tracer.StartSpan("rpc")
+	ctx := opentracing.ContextWithSpan(context.Background(), parent)
+
+	nRows, err := execContext(ctx, mockQueryer{}, "removeContent", "UPDATE comments SET is_deleted=true")
+	if err != nil || nRows != 3 {
+		t.Fatalf("unexpected result %v, %v", nRows, err)
+	}
+
+	spans := tracer.FinishedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("unexpected number of spans: got %v want %v", len(spans), 1)
+	}
+
+	span := spans[0]
+	if span.OperationName != "sql.removeContent" || span.ParentID != parent.Context().(mocktracer.MockSpanContext).SpanID {
+		t.Errorf("unexpected span %v", span)
+	}
+
+	if span.Tag(queryNameTag) != "removeContent" || span.Tag(rowsTag) != int64(3) {
+		t.Errorf("unexpected tags %v", span.Tags())
+	}
+}
+
+func TestQueryRowsSpanError(t *testing.T) {
+	tracer := mocktracer.New()
+	ctx := opentracing.ContextWithSpan(context.Background(), tracer.StartSpan("rpc"))
+
+	err := queryRows(ctx, mockQueryer{}, "getAll", func(*sql.Rows) error { return nil }, "SELECT 1")
+	if err != errDummy {
+		t.Errorf("unexpected error: got %v want %v", err, errDummy)
+	}
+
+	spans := tracer.FinishedSpans()
+	if len(spans) != 1 || spans[0].Tag("error") != true {
+		t.Errorf("error is not recorded in span")
+	}
+}
@@ -0,0 +1,153 @@
+package comment
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tenantHeader is the metadata key naming tenant on whose behalf a trusted caller acts
+const tenantHeader = "x-comment-tenant"
+
+// errNoTenantInContext is returned by datastore called outside of a request of a tenant
+var errNoTenantInContext = errors.New("no tenant in context")
+
+var (
+	statusNoTenant        = status.Error(codes.Unauthenticated, "tenant required")
+	statusTenantForbidden = status.Error(codes.PermissionDenied, "caller may not act on behalf of tenant")
+)
+
+type tenantKey struct{}
+
+// TenantConfig describes how tenant of a request is resolved.
+// Every comment belongs to exactly one tenant and requests only see comments of their tenant.
+type TenantConfig struct {
+	// Identities maps service identities to their tenants, such callers always act on behalf of their tenant
+	Identities map[string]string
+	// Trusted identities may choose tenant with x-comment-tenant metadata
+	Trusted []string
+	// Default is the tenant of requests which name no tenant, empty rejects such requests
+	Default string
+	// Settings override global settings for a tenant
+	Settings map[string]TenantSettings
+}
+
+// TenantSettings are settings of a tenant, zero fields fall back to global ones
+type TenantSettings struct {
+	Limits Limits
+	// GuestComments overrides Features.GuestComments if set
+	GuestComments *bool
+}
+
+// Validate checks that tenant config is consistent
+func (c TenantConfig) Validate() error {
+	for identity, tenant := range c.Identities {
+		if tenant == "" {
+			return fmt.Errorf("identity %s is mapped to empty tenant", identity)
+		}
+	}
+
+	for tenant, settings := range c.Settings {
+		if err := settings.Limits.validate(); err != nil {
+			return fmt.Errorf("tenant %s: %v", tenant, err)
+		}
+	}
+
+	return nil
+}
+
+// TenantFromContext returns tenant of current request
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// withTenant returns context of a request made on behalf of tenant
+func withTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+func (c TenantConfig) trusted(identity string) bool {
+	for _, trusted := range c.Trusted {
+		if trusted == identity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolve returns tenant of request: tenant of caller identity, tenant named by a trusted caller or default one
+func (c TenantConfig) resolve(ctx context.Context) (string, error) {
+	var requested string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tenantHeader); len(values) > 0 {
+			requested = values[0]
+		}
+	}
+
+	identity, hasIdentity := IdentityFromContext(ctx)
+	if tenant, ok := c.Identities[identity]; hasIdentity && ok {
+		if requested != "" && requested != tenant {
+			return "", statusTenantForbidden
+		}
+
+		return tenant, nil
+	}
+
+	if requested != "" {
+		if !hasIdentity || !c.trusted(identity) {
+			return "", statusTenantForbidden
+		}
+
+		return requested, nil
+	}
+
+	if c.Default == "" {
+		return "", statusNoTenant
+	}
+
+	return c.Default, nil
+}
+
+// limits returns limits of tenant, its own non-zero limits override global ones
+func (c Config) limits(tenant string) Limits {
+	return c.Limits.override(c.Tenants.Settings[tenant].Limits)
+}
+
+func (c TenantConfig) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		tenant, err := c.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(withTenant(ctx, tenant), req)
+	}
+}
+
+func (c TenantConfig) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		tenant, err := c.resolve(stream.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{stream, withTenant(stream.Context(), tenant)})
+	}
+}
+
+// tenantOf returns tenant every datastore query must be scoped to
+func tenantOf(ctx context.Context) (string, error) {
+	tenant := TenantFromContext(ctx)
+	if tenant == "" {
+		return "", errNoTenantInContext
+	}
+
+	return tenant, nil
+}
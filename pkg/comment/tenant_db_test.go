@@ -0,0 +1,256 @@
+package comment
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+)
+
+// testDBEnv names connection string of a Postgres database initialized with sql/script.sql,
+// tests against real database are skipped unless it is set
+const testDBEnv = "COMMENT_TEST_DB"
+
+func openTestDB(t *testing.T) *db {
+	connString := os.Getenv(testDBEnv)
+	if connString == "" {
+		t.Skipf("%s is not set", testDBEnv)
+	}
+
+	d, err := newDB(DBConfig{ConnString: connString})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	return d
+}
+
+// TestTenantIsolation creates comments in one tenant and checks that no datastore method
+// reads or changes them on behalf of another tenant
+func TestTenantIsolation(t *testing.T) {
+	d := openTestDB(t)
+	defer d.close()
+
+	suffix := uuid.New().String()
+	own := withTenant(context.Background(), "own-"+suffix)
+	other := withTenant(context.Background(), "other-"+suffix)
+
+	target := postTarget(uuid.New())
+	userUID := uuid.New()
+	parent, err := d.create(own, target, "parent", uuid.Nil, userUID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	reply, err := d.create(own, target, "reply", parent.UID, userUID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	guest := &Guest{Name: "guest", EmailHash: uuid.New().String(), ChallengeUID: uuid.New(), Pending: true}
+	guestComment, err := d.create(own, target, "guest", uuid.Nil, uuid.Nil, guest)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	removed, err := d.create(own, target, "removed", uuid.Nil, uuid.New(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := d.removeContent(own, removed.UID, Removal{By: removedByModerator, Reason: "spam", Notes: "notes"}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err := d.saveDraft(own, userUID, target, parent.UID, "draft", time.Time{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	body := "changed"
+	checks := map[string]func() error{
+		"getAll": func() error {
+			return expectEmpty(d.getAll(other, target, uuid.Nil, 10, 0))
+		},
+		"getOne": func() error {
+			_, err := d.getOne(other, parent.UID)
+			return expectErr(err, errNotFound)
+		},
+		"create": func() error {
+			_, err := d.create(other, target, "reply", parent.UID, userUID, nil)
+			return expectErr(err, errNoParent)
+		},
+		"update": func() error {
+			_, err := d.update(other, parent.UID, commentChanges{body: &body})
+			return expectErr(err, errNotFound)
+		},
+		"removeContent": func() error {
+			return expectErr(d.removeContent(other, parent.UID, Removal{}), errNotFound)
+		},
+		"restoreContent": func() error {
+			return expectErr(d.restoreContent(other, parent.UID), errNotFound)
+		},
+		"delete": func() error {
+			_, err := d.delete(other, reply.UID, deleteCascade, Removal{})
+			return expectErr(err, errNotFound)
+		},
+		"getOwner": func() error {
+			_, err := d.getOwner(other, parent.UID)
+			return expectErr(err, errNotFound)
+		},
+		"getAllByUser": func() error {
+			return expectEmpty(d.getAllByUser(other, userUID))
+		},
+		"getRevisionsByUser": func() error {
+			return expectEmpty(d.getRevisionsByUser(other, userUID))
+		},
+		"eraseUser": func() error {
+			return expectNone(d.eraseUser(other, userUID))
+		},
+		"deleteForTarget": func() error {
+			return expectNone(d.deleteForTarget(other, target, 100))
+		},
+		"removeContentForTarget": func() error {
+			return expectNone(d.removeContentForTarget(other, target, 100))
+		},
+		"getPending": func() error {
+			return expectEmpty(d.getPending(other, 10, 0))
+		},
+		"getRemoved": func() error {
+			return expectEmpty(d.getRemoved(other, "", 10, 0))
+		},
+		"approve": func() error {
+			_, err := d.approve(other, guestComment.UID)
+			return expectErr(err, errNotFound)
+		},
+		"claimGuest": func() error {
+			return expectNone(d.claimGuest(other, guest.EmailHash, uuid.New()))
+		},
+		"importComments": func() error {
+			// UID of comment of base tenant is taken, so nothing is written over it
+			return expectNone(d.importComments(other, []*Comment{parent}))
+		},
+		"saveDraft": func() error {
+			// saves a separate draft of other tenant, checked to be gone below
+			draft, err := d.saveDraft(other, userUID, target, parent.UID, "other", time.Time{})
+			if err != nil {
+				return err
+			}
+
+			if draft.Body != "other" {
+				return fmt.Errorf("expected new draft, got draft of other tenant %+v", draft)
+			}
+
+			return d.deleteDraft(other, userUID, target, parent.UID)
+		},
+		"getDraft": func() error {
+			_, err := d.getDraft(other, userUID, target, parent.UID, time.Time{})
+			return expectErr(err, errDraftNotFound)
+		},
+		"getDrafts": func() error {
+			return expectEmpty(d.getDrafts(other, userUID, time.Time{}, 10, 0))
+		},
+		"deleteDraft": func() error {
+			return expectErr(d.deleteDraft(other, userUID, target, parent.UID), errDraftNotFound)
+		},
+	}
+
+	methods := reflect.TypeOf((*datastore)(nil)).Elem()
+	for i := 0; i < methods.NumMethod(); i++ {
+		name := methods.Method(i).Name
+		if name == "ping" || name == "close" {
+			continue
+		}
+
+		check, ok := checks[name]
+		if !ok {
+			t.Errorf("%s is not checked for tenant isolation", name)
+			continue
+		}
+
+		if err := check(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+
+	comments, err := d.getAllByUser(own, userUID)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(comments) != 2 {
+		t.Fatalf("expected 2 comments, got %d", len(comments))
+	}
+
+	for _, comment := range comments {
+		if comment.IsDeleted || comment.UserUID != userUID || (comment.Body != "parent" && comment.Body != "reply") {
+			t.Errorf("comment changed by other tenant: %+v", comment)
+		}
+	}
+
+	pending, err := d.getPending(own, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(pending) != 1 || pending[0].GuestName != guest.Name {
+		t.Errorf("guest comment changed by other tenant: %v", pending)
+	}
+
+	removedComments, err := d.getRemoved(own, "spam", 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(removedComments) != 1 || removedComments[0].Removal.By != removedByModerator || removedComments[0].Removal.Notes != "notes" || removedComments[0].Removal.At.IsZero() {
+		t.Errorf("removed comment changed by other tenant: %v", removedComments)
+	}
+
+	draft, err := d.getDraft(own, userUID, target, parent.UID, time.Time{})
+	if err != nil || draft.Body != "draft" {
+		t.Errorf("draft changed by other tenant: %+v, %v", draft, err)
+	}
+
+	if _, err := d.eraseUser(own, userUID); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if _, err := d.deleteForTarget(own, target, 100); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func expectErr(err, expected error) error {
+	if err != expected {
+		return fmt.Errorf("expected %v, got %v", expected, err)
+	}
+
+	return nil
+}
+
+func expectEmpty(result interface{}, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if n := reflect.ValueOf(result).Len(); n != 0 {
+		return fmt.Errorf("expected nothing, got %d results of other tenant", n)
+	}
+
+	return nil
+}
+
+func expectNone(n int64, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if n != 0 {
+		return fmt.Errorf("expected no rows affected, got %d rows of other tenant", n)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package comment
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func tenantContext(identity, requested string) context.Context {
+	ctx := context.Background()
+	if identity != "" {
+		ctx = context.WithValue(ctx, identityKey{}, identity)
+	}
+
+	if requested != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(tenantHeader, requested))
+	}
+
+	return ctx
+}
+
+func TestResolveTenant(t *testing.T) {
+	c := TenantConfig{
+		Identities: map[string]string{"blog": "blog"},
+		Trusted:    []string{"moderation"},
+		Default:    "default",
+	}
+
+	tests := []struct {
+		name      string
+		identity  string
+		requested string
+		tenant    string
+		err       error
+	}{
+		{"mapped identity", "blog", "", "blog", nil},
+		{"mapped identity names own tenant", "blog", "blog", "blog", nil},
+		{"mapped identity names other tenant", "blog", "forum", "", statusTenantForbidden},
+		{"trusted identity", "moderation", "forum", "forum", nil},
+		{"untrusted identity", "gateway", "forum", "", statusTenantForbidden},
+		{"anonymous caller", "", "forum", "", statusTenantForbidden},
+		{"default tenant", "gateway", "", "default", nil},
+	}
+
+	for _, test := range tests {
+		tenant, err := c.resolve(tenantContext(test.identity, test.requested))
+		if tenant != test.tenant || err != test.err {
+			t.Errorf("%s: expected %q, %v, got %q, %v", test.name, test.tenant, test.err, tenant, err)
+		}
+	}
+
+	c.Default = ""
+	if _, err := c.resolve(tenantContext("gateway", "")); err != statusNoTenant {
+		t.Errorf("expected %v, got %v", statusNoTenant, err)
+	}
+}
+
+func TestTenantInterceptor(t *testing.T) {
+	c := TenantConfig{Identities: map[string]string{"blog": "blog"}}
+
+	var tenant string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		tenant = TenantFromContext(ctx)
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/comment.Comment/ListComments"}
+	if _, err := c.unaryInterceptor()(tenantContext("blog", ""), nil, info, handler); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if tenant != "blog" {
+		t.Errorf("expected tenant blog, got %q", tenant)
+	}
+
+	if _, err := c.unaryInterceptor()(tenantContext("gateway", ""), nil, info, handler); err != statusNoTenant {
+		t.Errorf("expected %v, got %v", statusNoTenant, err)
+	}
+}
+
+func TestTenantLimits(t *testing.T) {
+	c := Config{
+		Limits: Limits{MaxBodyLength: 100, DefaultPageSize: 10, MaxPageSize: 50},
+		Tenants: TenantConfig{Settings: map[string]TenantSettings{
+			"blog": {Limits: Limits{MaxBodyLength: 1000}},
+		}},
+	}
+
+	if limits := c.limits("blog"); limits != (Limits{MaxBodyLength: 1000, DefaultPageSize: 10, MaxPageSize: 50}) {
+		t.Errorf("unexpected limits %+v", limits)
+	}
+
+	if limits := c.limits("forum"); limits != c.Limits {
+		t.Errorf("unexpected limits %+v", limits)
+	}
+
+	c.Tenants.Settings["forum"] = TenantSettings{Limits: Limits{DefaultPageSize: 100}}
+	if err := c.Tenants.Validate(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	c.Port, c.DB.ConnString, c.TLS.Insecure = 8080, "postgres://", true
+	if err := c.Validate(); err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+
+	c.Tenants.Settings["forum"] = TenantSettings{}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	c.Tenants.Trusted = []string{anonymousIdentity}
+	if err := c.Validate(); err == nil {
+		t.Errorf("expected error of trusted anonymous identity, got nothing")
+	}
+}
+
+func TestTenantOf(t *testing.T) {
+	if _, err := tenantOf(context.Background()); err != errNoTenantInContext {
+		t.Errorf("expected %v, got %v", errNoTenantInContext, err)
+	}
+
+	if tenant, err := tenantOf(withTenant(context.Background(), "blog")); tenant != "blog" || err != nil {
+		t.Errorf("expected blog, got %q, %v", tenant, err)
+	}
+}
@@ -0,0 +1,199 @@
+package comment
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// certCheckInterval limits how often certificate files are checked for changes
+var certCheckInterval = 10 * time.Second
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+}
+
+// TLSConfig describes transport security of server
+type TLSConfig struct {
+	// CertFile and KeyFile are paths to PEM encoded server certificate and key
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is a path to PEM encoded CA bundle; if set clients must present a certificate signed by it
+	ClientCAFile string
+	// MinVersion is the minimum accepted TLS version, TLS 1.2 if zero
+	MinVersion uint16
+	// Insecure disables TLS completely, use for local development only
+	Insecure bool
+}
+
+// ParseTLSVersion converts version string such as "1.2" to TLS version
+func ParseTLSVersion(version string) (uint16, error) {
+	if v, ok := tlsVersions[version]; ok {
+		return v, nil
+	}
+
+	return 0, fmt.Errorf("unsupported TLS version %q", version)
+}
+
+// transportCredentials returns gRPC transport credentials, nil if TLS is disabled
+func (c TLSConfig) transportCredentials() (credentials.TransportCredentials, error) {
+	config, err := c.serverConfig(tls.RequireAndVerifyClientCert)
+	if err != nil || config == nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// serverConfig returns server TLS config, nil if TLS is disabled.
+// If client CA is configured, client certificates are checked according to clientAuth.
+func (c TLSConfig) serverConfig(clientAuth tls.ClientAuthType) (*tls.Config, error) {
+	if c.Insecure {
+		return nil, nil
+	}
+
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, errors.New("TLS certificate and key are required unless insecure mode is enabled")
+	}
+
+	reloader := &certReloader{certFile: c.CertFile, keyFile: c.KeyFile}
+	if clientAuth != tls.NoClientCert {
+		reloader.caFile = c.ClientCAFile
+	}
+
+	if err := reloader.load(); err != nil {
+		return nil, err
+	}
+
+	minVersion := c.MinVersion
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS12
+	}
+
+	return &tls.Config{
+		MinVersion: minVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			reloader.maybeReload()
+			cert, clientCAs := reloader.get()
+
+			conf := &tls.Config{
+				MinVersion:   minVersion,
+				Certificates: []tls.Certificate{*cert},
+			}
+			if clientCAs != nil {
+				conf.ClientCAs = clientCAs
+				conf.ClientAuth = clientAuth
+			}
+
+			return conf, nil
+		},
+	}, nil
+}
+
+// certReloader keeps server certificate and client CAs up to date with files on disk
+type certReloader struct {
+	certFile, keyFile, caFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+func (r *certReloader) get() (*tls.Certificate, *x509.CertPool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, r.clientCAs
+}
+
+// maybeReload reloads files if they were modified since last load.
+// On failure previously loaded certificate is kept.
+func (r *certReloader) maybeReload() {
+	r.mu.Lock()
+	if time.Since(r.checkedAt) < certCheckInterval {
+		r.mu.Unlock()
+		return
+	}
+
+	r.checkedAt = time.Now()
+	loadedAt := r.modTime
+	r.mu.Unlock()
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		log.Printf("checking TLS files failed: %v", err)
+		return
+	}
+
+	if !modTime.After(loadedAt) {
+		return
+	}
+
+	if err := r.load(); err != nil {
+		log.Printf("reloading TLS files failed: %v", err)
+	}
+}
+
+func (r *certReloader) load() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	var clientCAs *x509.CertPool
+	if r.caFile != "" {
+		pem, err := ioutil.ReadFile(r.caFile)
+		if err != nil {
+			return err
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in %s", r.caFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTime = modTime
+	r.checkedAt = time.Now()
+	return nil
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{r.certFile, r.keyFile, r.caFile} {
+		if name == "" {
+			continue
+		}
+
+		info, err := os.Stat(name)
+		if err != nil {
+			return latest, err
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
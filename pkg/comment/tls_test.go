@@ -0,0 +1,141 @@
+package comment
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert writes self-signed certificate with given common name and its key to dir
+func writeCert(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, cert *tls.Certificate) string {
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return parsed.Subject.CommonName
+}
+
+func TestCertReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "comment-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeCert(t, dir, "first")
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.load(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	cert, _ := r.get()
+	if name := commonName(t, cert); name != "first" {
+		t.Errorf("unexpected certificate: got %v want %v", name, "first")
+	}
+
+	defer func(interval time.Duration) { certCheckInterval = interval }(certCheckInterval)
+	certCheckInterval = 0
+
+	writeCert(t, dir, "second")
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(certFile, later, later)
+	r.maybeReload()
+
+	cert, _ = r.get()
+	if name := commonName(t, cert); name != "second" {
+		t.Errorf("certificate not reloaded: got %v want %v", name, "second")
+	}
+
+	ioutil.WriteFile(keyFile, []byte("garbage"), 0600)
+	later = later.Add(time.Minute)
+	os.Chtimes(keyFile, later, later)
+	r.maybeReload()
+
+	cert, _ = r.get()
+	if name := commonName(t, cert); name != "second" {
+		t.Errorf("broken certificate replaced working one: got %v want %v", name, "second")
+	}
+}
+
+func TestTransportCredentials(t *testing.T) {
+	creds, err := TLSConfig{Insecure: true}.transportCredentials()
+	if err != nil || creds != nil {
+		t.Errorf("expected no credentials in insecure mode, got %v %v", creds, err)
+	}
+
+	_, err = TLSConfig{}.transportCredentials()
+	if err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+
+	_, err = TLSConfig{CertFile: "/nonexistent.pem", KeyFile: "/nonexistent.pem"}.transportCredentials()
+	if err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}
+
+func TestParseTLSVersion(t *testing.T) {
+	v, err := ParseTLSVersion("1.2")
+	if err != nil || v != tls.VersionTLS12 {
+		t.Errorf("unexpected result %v %v", v, err)
+	}
+
+	_, err = ParseTLSVersion("2.0")
+	if err == nil {
+		t.Errorf("expected error, got nothing")
+	}
+}